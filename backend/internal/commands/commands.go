@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"codewithdell/backend/internal/config"
+	"codewithdell/backend/internal/database"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// command is a maintenance task run from the command line instead of the server
+type command struct {
+	usage string
+	run   func(cfg *config.Config, args []string) error
+}
+
+// registry maps command names to commands
+var registry = map[string]command{
+	"export": {usage: exportUsage, run: runExport},
+	"import": {usage: importUsage, run: runImport},
+}
+
+// Run runs the command named by the first argument
+func Run(cfg *config.Config, args []string) error {
+	cmd, ok := registry[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], Usage())
+	}
+	return cmd.run(cfg, args[1:])
+}
+
+// Usage lists the available commands
+func Usage() string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("Commands:\n")
+	for _, name := range names {
+		b.WriteString("  " + registry[name].usage + "\n")
+	}
+	return b.String()
+}
+
+// connect opens the database and brings its schema up to date. SQL logging
+// is limited to warnings so it does not drown the command output.
+func connect(cfg *config.Config) (*gorm.DB, error) {
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	if err := database.RunMigrations(cfg.Database); err != nil {
+		return nil, err
+	}
+	return db.Session(&gorm.Session{Logger: db.Logger.LogMode(gormlogger.Warn)}), nil
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"codewithdell/backend/internal/config"
+	"codewithdell/backend/internal/exporter"
+)
+
+const exportUsage = "export [-uploads dir] [-o archive.zip|-]"
+
+// runExport writes the whole site into a zip archive that import restores
+func runExport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	uploads := flags.String("uploads", "uploads", "directory of uploaded media to include")
+	output := flags.String("o", "", "archive to write, - for standard output (default: codewithdell-export-<time>.zip)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New("usage: " + exportUsage)
+	}
+
+	db, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	name := *output
+	if name == "" {
+		name = "codewithdell-export-" + time.Now().Format("20060102-150405") + ".zip"
+	}
+
+	var w io.Writer = os.Stdout
+	if name != "-" {
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := exporter.New(db, *uploads).Write(context.Background(), w); err != nil {
+		if name != "-" {
+			os.Remove(name)
+		}
+		return err
+	}
+
+	if name != "-" {
+		fmt.Fprintf(os.Stderr, "Exported site to %s\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"codewithdell/backend/internal/config"
+	"codewithdell/backend/internal/importer"
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const importUsage = "import [-dry-run] [-json] [-author email] [-uploads dir] [-media dir|archive.zip] <directory|archive.zip|wordpress.xml>"
+
+// runImport imports posts from a directory or zip archive of Markdown files
+// or from a WordPress export, or restores a site export
+func runImport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report what would change without writing anything")
+	author := flags.String("author", "", "email or username of the author of new posts (default: the first admin)")
+	uploads := flags.String("uploads", "uploads", "directory referenced images are copied into")
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	media := flags.String("media", "", "wp-content/uploads directory or zip archive WordPress media is copied from")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: " + importUsage)
+	}
+
+	db, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	authorID, err := findAuthor(db, *author)
+	if err != nil {
+		return err
+	}
+
+	im := importer.New(db, importer.Options{
+		AuthorID:  authorID,
+		UploadDir: *uploads,
+		DryRun:    *dryRun,
+	})
+	var report *importer.Report
+	if strings.EqualFold(filepath.Ext(flags.Arg(0)), ".xml") {
+		report, err = runWordPressImport(im, flags.Arg(0), *media)
+	} else {
+		report, err = runMarkdownImport(im, flags.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printImportReport(report)
+	return nil
+}
+
+// runMarkdownImport imports a directory or zip archive of Markdown files
+func runMarkdownImport(im *importer.Importer, name string) (*importer.Report, error) {
+	fsys, closer, err := importer.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return im.Run(fsys)
+}
+
+// runWordPressImport imports a WordPress export, copying media from the
+// uploads directory or archive when one is given
+func runWordPressImport(im *importer.Importer, name, media string) (*importer.Report, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var mediaFS fs.FS
+	if media != "" {
+		fsys, closer, err := importer.Open(media)
+		if err != nil {
+			return nil, err
+		}
+		defer closer.Close()
+		mediaFS = fsys
+	}
+	return im.RunWordPress(file, mediaFS)
+}
+
+// findAuthor returns the id of the user with the given email or username, or
+// of the first admin when none is given
+func findAuthor(db *gorm.DB, author string) (uint, error) {
+	var user models.User
+	query := db.Order("id ASC")
+	if author != "" {
+		query = query.Where("email = ? OR username = ?", author, author)
+	} else {
+		query = query.Where("role = ?", models.RoleAdmin)
+	}
+
+	if err := query.First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if author != "" {
+				return 0, fmt.Errorf("user %q not found", author)
+			}
+			return 0, errors.New("no admin user found, pass -author")
+		}
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// printImportReport prints one line per file followed by the totals
+func printImportReport(report *importer.Report) {
+	for _, item := range report.Items {
+		line := fmt.Sprintf("%-9s %s", item.Action, item.File)
+		if item.Slug != "" {
+			line += " -> " + item.Slug
+		}
+		if item.Error != "" {
+			line += ": " + item.Error
+		}
+		fmt.Println(line)
+
+		if len(item.Images) > 0 {
+			fmt.Printf("          images: %s\n", strings.Join(item.Images, ", "))
+		}
+		if len(item.NewTags) > 0 {
+			fmt.Printf("          new tags: %s\n", strings.Join(item.NewTags, ", "))
+		}
+		if len(item.NewCategories) > 0 {
+			fmt.Printf("          new categories: %s\n", strings.Join(item.NewCategories, ", "))
+		}
+		if item.Comments > 0 {
+			fmt.Printf("          new comments: %d\n", item.Comments)
+		}
+		for _, warning := range item.Warnings {
+			fmt.Printf("          warning: %s\n", warning)
+		}
+	}
+
+	if wordpress := report.WordPress; wordpress != nil {
+		fmt.Printf("\nNew: %d users, %d categories, %d tags, %d comments, %d media files\n",
+			wordpress.Users, wordpress.Categories, wordpress.Tags, wordpress.Comments, wordpress.Media)
+	}
+	if restored := report.Restored; restored != nil {
+		fmt.Printf("\nRestored %d media files, %d categories, %d tags, %d technologies, %d screenshots\n",
+			restored.Media, restored.Categories, restored.Tags, restored.Technologies, restored.Screenshots)
+	}
+
+	prefix := ""
+	if report.DryRun {
+		prefix = "Dry run: "
+	}
+	fmt.Printf("\n%s%d created, %d updated, %d unchanged, %d failed\n",
+		prefix, report.Created, report.Updated, report.Unchanged, report.Failed)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -17,6 +18,9 @@ type Config struct {
 	JWT      JWTConfig
 	Email    EmailConfig
 	Storage  StorageConfig
+	Jobs     JobsConfig
+	I18n     I18nConfig
+	Publish  PublishConfig
 }
 
 // AppConfig holds application configuration
@@ -70,6 +74,28 @@ type StorageConfig struct {
 	SecretKey string
 }
 
+// JobsConfig holds background job configuration
+type JobsConfig struct {
+	PublishInterval    time.Duration
+	ArchiveInterval    time.Duration
+	TrashPurgeInterval time.Duration
+	// TrashRetention is how long deleted content stays in the trash
+	TrashRetention time.Duration
+}
+
+// I18nConfig holds content localization configuration
+type I18nConfig struct {
+	DefaultLocale string
+	Locales       []string
+}
+
+// PublishConfig holds content publishing configuration
+type PublishConfig struct {
+	// BlockOnLintErrors refuses to publish or schedule posts whose lint
+	// report has errors
+	BlockOnLintErrors bool
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	config := &Config{
@@ -112,6 +138,19 @@ func Load() (*Config, error) {
 			AccessKey: getEnv("STORAGE_ACCESS_KEY", ""),
 			SecretKey: getEnv("STORAGE_SECRET_KEY", ""),
 		},
+		Jobs: JobsConfig{
+			PublishInterval:    getEnvAsDuration("JOBS_PUBLISH_INTERVAL", time.Minute),
+			ArchiveInterval:    getEnvAsDuration("JOBS_ARCHIVE_INTERVAL", time.Hour),
+			TrashPurgeInterval: getEnvAsDuration("JOBS_TRASH_PURGE_INTERVAL", time.Hour),
+			TrashRetention:     getEnvAsDuration("TRASH_RETENTION", 30*24*time.Hour),
+		},
+		I18n: I18nConfig{
+			DefaultLocale: getEnv("DEFAULT_LOCALE", "en"),
+			Locales:       getEnvAsList("SUPPORTED_LOCALES", []string{"en", "id"}),
+		},
+		Publish: PublishConfig{
+			BlockOnLintErrors: getEnvAsBool("PUBLISH_BLOCK_ON_LINT_ERRORS", false),
+		},
 	}
 
 	// Validate configuration
@@ -137,6 +176,16 @@ func (c *Config) validate() error {
 		return fmt.Errorf("JWT secret is required")
 	}
 
+	defaultSupported := false
+	for _, locale := range c.I18n.Locales {
+		if locale == c.I18n.DefaultLocale {
+			defaultSupported = true
+		}
+	}
+	if !defaultSupported {
+		return fmt.Errorf("default locale %q must be one of the supported locales", c.I18n.DefaultLocale)
+	}
+
 	return nil
 }
 
@@ -168,6 +217,30 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsList(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		if len(list) > 0 {
+			return list
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"codewithdell/backend/internal/config"
+	"codewithdell/backend/internal/markdown"
 	"codewithdell/backend/internal/models"
 
 	"gorm.io/driver/postgres"
@@ -70,15 +71,73 @@ func RunMigrations(cfg config.DatabaseConfig) error {
 		&models.Like{},
 		&models.Bookmark{},
 		&models.Screenshot{},
+		&models.PostRevision{},
+		&models.Series{},
+		&models.PreviewToken{},
+		&models.Contributor{},
+		&models.SlugHistory{},
+		&models.Translation{},
+		&models.ArchiveRule{},
+		&models.AuditEntry{},
+		&models.FeaturedSlot{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if err := backfillContributors(DB); err != nil {
+		return fmt.Errorf("failed to backfill contributors: %w", err)
+	}
+
+	if err := backfillPostMetrics(DB); err != nil {
+		return fmt.Errorf("failed to backfill post metrics: %w", err)
+	}
+
 	return nil
 }
 
+// backfillContributors credits the author of every post and project that has no contributors yet
+func backfillContributors(db *gorm.DB) error {
+	if err := db.Exec(`INSERT INTO contributors (user_id, post_id, role, position, created_at)
+		SELECT posts.author_id, posts.id, ?, 0, NOW() FROM posts
+		WHERE NOT EXISTS (SELECT 1 FROM contributors WHERE contributors.post_id = posts.id)`,
+		models.ContributorRoleAuthor).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`INSERT INTO contributors (user_id, project_id, role, position, created_at)
+		SELECT projects.author_id, projects.id, ?, 0, NOW() FROM projects
+		WHERE NOT EXISTS (SELECT 1 FROM contributors WHERE contributors.project_id = projects.id)`,
+		models.ContributorRoleAuthor).Error
+}
+
+// backfillPostMetrics computes reading metrics, and an excerpt where missing,
+// for posts written before they were stored. Every post with content gets a
+// reading time of at least a minute, so posts without prose are only
+// backfilled once.
+func backfillPostMetrics(db *gorm.DB) error {
+	var posts []models.Post
+	return db.Select("id", "content", "excerpt").
+		Where("word_count = 0 AND reading_time = 0 AND content <> ''").
+		FindInBatches(&posts, 100, func(tx *gorm.DB, batch int) error {
+			for _, post := range posts {
+				summary := markdown.Analyze(post.Content)
+				updates := map[string]interface{}{
+					"word_count":   summary.WordCount,
+					"reading_time": summary.ReadingTime,
+				}
+				if post.Excerpt == "" {
+					updates["excerpt"] = summary.Excerpt
+				}
+				if err := db.Model(&models.Post{}).Where("id = ?", post.ID).UpdateColumns(updates).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
 // GetDB returns the database instance
 func GetDB() *gorm.DB {
 	return DB
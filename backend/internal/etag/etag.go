@@ -0,0 +1,70 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Format returns the strong entity tag of a content version
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// FormatBody returns the strong entity tag of a response showing a content
+// version. It adds a digest of the body to the version, so the tag changes
+// with everything the response embeds, such as related content and the
+// locale, while If-Match still compares the version alone.
+func FormatBody(version int, body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + strconv.Itoa(version) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// Parse returns the version of a strong entity tag written by Format or FormatBody
+func Parse(tag string) (int, bool) {
+	tag = strings.TrimSpace(tag)
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	value := tag[1 : len(tag)-1]
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		value = value[:i]
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// Match reports whether an If-Match header matches a version. It uses the
+// strong comparison, so weak tags never match.
+func Match(header string, version int) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if v, ok := Parse(tag); ok && v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// NoneMatch reports whether an If-None-Match header matches an entity tag.
+// It uses the weak comparison, so W/ prefixes are ignored.
+func NoneMatch(header string, tag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
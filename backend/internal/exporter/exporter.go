@@ -0,0 +1,399 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"codewithdell/backend/internal/importer"
+	"codewithdell/backend/internal/models"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// batchSize is the number of posts or projects loaded at a time
+const batchSize = 100
+
+// contributorFrontMatter credits a user, by email, on an exported post or project
+type contributorFrontMatter struct {
+	User string `yaml:"user"`
+	Role string `yaml:"role"`
+}
+
+// postFrontMatter is the front matter of an exported post. Author is the
+// email of the author.
+type postFrontMatter struct {
+	Title        string                   `yaml:"title"`
+	Slug         string                   `yaml:"slug"`
+	Status       string                   `yaml:"status"`
+	Date         *time.Time               `yaml:"date,omitempty"`
+	ExpiresAt    *time.Time               `yaml:"expires_at,omitempty"`
+	Summary      string                   `yaml:"summary,omitempty"`
+	Image        string                   `yaml:"image,omitempty"`
+	Author       string                   `yaml:"author,omitempty"`
+	Contributors []contributorFrontMatter `yaml:"contributors,omitempty"`
+	Tags         []string                 `yaml:"tags,omitempty"`
+	Categories   []string                 `yaml:"categories,omitempty"`
+}
+
+// projectFrontMatter is the front matter of an exported project. Author is
+// the email of the author.
+type projectFrontMatter struct {
+	Title        string                   `yaml:"title"`
+	Slug         string                   `yaml:"slug"`
+	Status       string                   `yaml:"status"`
+	Date         *time.Time               `yaml:"date,omitempty"`
+	Description  string                   `yaml:"description,omitempty"`
+	Image        string                   `yaml:"image,omitempty"`
+	Author       string                   `yaml:"author,omitempty"`
+	Contributors []contributorFrontMatter `yaml:"contributors,omitempty"`
+	Tags         []string                 `yaml:"tags,omitempty"`
+	Categories   []string                 `yaml:"categories,omitempty"`
+	Technologies []string                 `yaml:"technologies,omitempty"`
+	LiveURL      string                   `yaml:"live_url,omitempty"`
+	SourceURL    string                   `yaml:"source_url,omitempty"`
+	DemoURL      string                   `yaml:"demo_url,omitempty"`
+	Difficulty   string                   `yaml:"difficulty,omitempty"`
+	Duration     string                   `yaml:"duration,omitempty"`
+	TeamSize     int                      `yaml:"team_size"`
+}
+
+// Exporter writes the whole site into a zip archive of Markdown files with
+// front matter, JSON manifests and uploaded media, which the importer restores
+type Exporter struct {
+	db        *gorm.DB
+	uploadDir string
+}
+
+// New creates a new site exporter
+func New(db *gorm.DB, uploadDir string) *Exporter {
+	if uploadDir == "" {
+		uploadDir = "uploads"
+	}
+	return &Exporter{db: db, uploadDir: uploadDir}
+}
+
+// Write streams the archive into w. Posts and projects are loaded in batches,
+// so the export does not hold the whole site in memory.
+func (e *Exporter) Write(ctx context.Context, w io.Writer) error {
+	db := e.db.WithContext(ctx)
+	archive := zip.NewWriter(w)
+	counts := make(map[string]int)
+
+	categories, err := e.categories(db)
+	if err != nil {
+		return err
+	}
+	counts["categories"] = len(categories)
+	if err := writeJSON(archive, importer.CategoriesFile, categories); err != nil {
+		return err
+	}
+
+	var tags []models.Tag
+	if err := db.Order("name ASC").Find(&tags).Error; err != nil {
+		return err
+	}
+	tagRecords := make([]importer.TagRecord, len(tags))
+	for i, tag := range tags {
+		tagRecords[i] = importer.TagRecord{Name: tag.Name, Slug: tag.Slug, Color: tag.Color}
+	}
+	counts["tags"] = len(tagRecords)
+	if err := writeJSON(archive, importer.TagsFile, tagRecords); err != nil {
+		return err
+	}
+
+	var technologies []models.Technology
+	if err := db.Order("name ASC").Find(&technologies).Error; err != nil {
+		return err
+	}
+	technologyRecords := make([]importer.TechnologyRecord, len(technologies))
+	for i, technology := range technologies {
+		technologyRecords[i] = importer.TechnologyRecord{
+			Name:        technology.Name,
+			Slug:        technology.Slug,
+			Description: technology.Description,
+			Icon:        technology.Icon,
+			Color:       technology.Color,
+			Website:     technology.Website,
+		}
+	}
+	counts["technologies"] = len(technologyRecords)
+	if err := writeJSON(archive, importer.TechnologiesFile, technologyRecords); err != nil {
+		return err
+	}
+
+	if counts["posts"], err = e.writePosts(db, archive); err != nil {
+		return err
+	}
+
+	screenshots := []importer.ScreenshotRecord{}
+	if counts["projects"], err = e.writeProjects(db, archive, &screenshots); err != nil {
+		return err
+	}
+	counts["screenshots"] = len(screenshots)
+	if err := writeJSON(archive, importer.ScreenshotsFile, screenshots); err != nil {
+		return err
+	}
+
+	if counts["media"], err = e.writeMedia(ctx, archive); err != nil {
+		return err
+	}
+
+	manifest := importer.Manifest{
+		Format:     importer.ArchiveFormat,
+		Version:    importer.ArchiveVersion,
+		ExportedAt: time.Now().UTC(),
+		Counts:     counts,
+	}
+	if err := writeJSON(archive, importer.ManifestFile, manifest); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// categories returns the category records with parents before their children
+func (e *Exporter) categories(db *gorm.DB) ([]importer.CategoryRecord, error) {
+	var categories []models.Category
+	if err := db.Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	slugs := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		slugs[category.ID] = category.Slug
+	}
+
+	records := []importer.CategoryRecord{}
+	var walk func(nodes []models.Category)
+	walk = func(nodes []models.Category) {
+		for _, node := range nodes {
+			record := importer.CategoryRecord{
+				Name:        node.Name,
+				Slug:        node.Slug,
+				Description: node.Description,
+				Color:       node.Color,
+				Icon:        node.Icon,
+			}
+			if node.ParentID != nil {
+				record.Parent = slugs[*node.ParentID]
+			}
+			records = append(records, record)
+			walk(node.Children)
+		}
+	}
+	walk(models.BuildCategoryTree(categories))
+	return records, nil
+}
+
+// writePosts writes every post as posts/<slug>.md
+func (e *Exporter) writePosts(db *gorm.DB, archive *zip.Writer) (int, error) {
+	count := 0
+	var posts []models.Post
+	err := db.Preload("Author").Preload("Contributors", orderedContributors).Preload("Contributors.User").
+		Preload("Tags").Preload("Categories").Order("id ASC").
+		FindInBatches(&posts, batchSize, func(tx *gorm.DB, batch int) error {
+			for _, post := range posts {
+				frontMatter := postFrontMatter{
+					Title:        post.Title,
+					Slug:         post.Slug,
+					Status:       string(post.Status),
+					Date:         post.PublishedAt,
+					ExpiresAt:    post.ExpiresAt,
+					Summary:      post.Excerpt,
+					Image:        post.FeaturedImage,
+					Author:       post.Author.Email,
+					Contributors: contributors(post.Contributors),
+					Tags:         tagNames(post.Tags),
+					Categories:   categoryNames(post.Categories),
+				}
+				if err := writeMarkdown(archive, path.Join(importer.PostsDir, post.Slug+".md"), frontMatter, post.Content); err != nil {
+					return err
+				}
+				count++
+			}
+			return nil
+		}).Error
+	return count, err
+}
+
+// writeProjects writes every project as projects/<slug>.md and collects their screenshots
+func (e *Exporter) writeProjects(db *gorm.DB, archive *zip.Writer, screenshots *[]importer.ScreenshotRecord) (int, error) {
+	count := 0
+	var projects []models.Project
+	err := db.Preload("Author").Preload("Contributors", orderedContributors).Preload("Contributors.User").
+		Preload("Tags").Preload("Categories").Preload("Technologies").
+		Preload("Screenshots", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"order" ASC, id ASC`)
+		}).
+		Order("id ASC").
+		FindInBatches(&projects, batchSize, func(tx *gorm.DB, batch int) error {
+			for _, project := range projects {
+				technologies := make([]string, len(project.Technologies))
+				for i, technology := range project.Technologies {
+					technologies[i] = technology.Name
+				}
+
+				frontMatter := projectFrontMatter{
+					Title:        project.Title,
+					Slug:         project.Slug,
+					Status:       string(project.Status),
+					Date:         project.PublishedAt,
+					Description:  project.Description,
+					Image:        project.FeaturedImage,
+					Author:       project.Author.Email,
+					Contributors: contributors(project.Contributors),
+					Tags:         tagNames(project.Tags),
+					Categories:   categoryNames(project.Categories),
+					Technologies: technologies,
+					LiveURL:      project.LiveURL,
+					SourceURL:    project.SourceURL,
+					DemoURL:      project.DemoURL,
+					Difficulty:   string(project.Difficulty),
+					Duration:     project.Duration,
+					TeamSize:     project.TeamSize,
+				}
+				if err := writeMarkdown(archive, path.Join(importer.ProjectsDir, project.Slug+".md"), frontMatter, project.Content); err != nil {
+					return err
+				}
+
+				for _, screenshot := range project.Screenshots {
+					*screenshots = append(*screenshots, importer.ScreenshotRecord{
+						Project:  project.Slug,
+						Title:    screenshot.Title,
+						ImageURL: screenshot.ImageURL,
+						AltText:  screenshot.AltText,
+						Order:    screenshot.Order,
+					})
+				}
+				count++
+			}
+			return nil
+		}).Error
+	return count, err
+}
+
+// writeMedia copies the upload directory into uploads/
+func (e *Exporter) writeMedia(ctx context.Context, archive *zip.Writer) (int, error) {
+	count := 0
+	err := filepath.WalkDir(e.uploadDir, func(name string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && name == e.uploadDir {
+			return filepath.SkipDir
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(e.uploadDir, name)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(importer.UploadsDir, filepath.ToSlash(rel))
+		header.Method = zip.Deflate
+		dst, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		src, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		if _, err := io.Copy(dst, src); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// writeMarkdown writes a Markdown file with YAML front matter
+func writeMarkdown(archive *zip.Writer, name string, frontMatter interface{}, body string) error {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(frontMatter); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	buf.WriteString("---\n\n")
+	buf.WriteString(body)
+
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeJSON writes an indented JSON file
+func writeJSON(archive *zip.Writer, name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// orderedContributors preloads contributors sorted by their credit position
+func orderedContributors(db *gorm.DB) *gorm.DB {
+	return db.Order("contributors.position ASC, contributors.id ASC")
+}
+
+// contributors returns the front matter of ordered contributors
+func contributors(list []models.Contributor) []contributorFrontMatter {
+	records := make([]contributorFrontMatter, len(list))
+	for i, contributor := range list {
+		records[i] = contributorFrontMatter{User: contributor.User.Email, Role: string(contributor.Role)}
+	}
+	return records
+}
+
+// tagNames returns the names of tags
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// categoryNames returns the names of categories
+func categoryNames(categories []models.Category) []string {
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+	return names
+}
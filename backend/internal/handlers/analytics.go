@@ -232,14 +232,16 @@ func getEngagementStats(db *gorm.DB) map[string]interface{} {
 		Scan(&avgComments)
 
 	// Engagement rate (likes + comments / views)
-	var totalViews int64
-	var totalLikes int64
-	var totalComments int64
-	
+	var totals struct {
+		Views    int64
+		Likes    int64
+		Comments int64
+	}
 	db.Model(&models.Post{}).
 		Where("status = ?", models.PostStatusPublished).
-		Select("SUM(view_count), SUM(like_count), SUM(comment_count)").
-		Scan(&totalViews, &totalLikes, &totalComments)
+		Select("COALESCE(SUM(view_count), 0) AS views, COALESCE(SUM(like_count), 0) AS likes, COALESCE(SUM(comment_count), 0) AS comments").
+		Scan(&totals)
+	totalViews, totalLikes, totalComments := totals.Views, totals.Likes, totals.Comments
 
 	engagementRate := float64(0)
 	if totalViews > 0 {
@@ -322,9 +324,15 @@ func GetUserStats(c *gin.Context) {
 		return
 	}
 
-	// Count user's posts
+	// Count user's posts, including co-authored ones
 	var postCount int64
-	db.Model(&models.Post{}).Where("author_id = ? AND status = ?", user.ID, models.PostStatusPublished).Count(&postCount)
+	db.Model(&models.Post{}).Where(userPostsCondition, user.ID, user.ID).
+		Where("status = ?", models.PostStatusPublished).Count(&postCount)
+
+	// Count user's projects, including co-authored ones
+	var projectCount int64
+	db.Model(&models.Project{}).Where(userProjectsCondition, user.ID, user.ID).
+		Where("status = ?", models.ProjectStatusPublished).Count(&projectCount)
 
 	// Count user's comments
 	var commentCount int64
@@ -340,7 +348,8 @@ func GetUserStats(c *gin.Context) {
 
 	// Get user's recent posts
 	var recentPosts []models.Post
-	db.Where("author_id = ? AND status = ?", user.ID, models.PostStatusPublished).
+	db.Where(userPostsCondition, user.ID, user.ID).
+		Where("status = ?", models.PostStatusPublished).
 		Order("created_at DESC").
 		Limit(5).
 		Find(&recentPosts)
@@ -357,6 +366,7 @@ func GetUserStats(c *gin.Context) {
 		"user_id":         user.ID,
 		"username":        user.Username,
 		"post_count":      postCount,
+		"project_count":   projectCount,
 		"comment_count":   commentCount,
 		"like_count":      likeCount,
 		"bookmark_count":  bookmarkCount,
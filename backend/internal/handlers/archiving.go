@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ArchiveRuleRequest represents an archive rule creation or update request
+type ArchiveRuleRequest struct {
+	Name         string `json:"name" binding:"required,min=3,max=100"`
+	CategoryID   *uint  `json:"category_id"`
+	MaxAgeMonths int    `json:"max_age_months" binding:"required,min=1,max=600"`
+	Enabled      *bool  `json:"enabled"`
+}
+
+// errExpiryInPast is returned when a post expiry time is not in the future
+var errExpiryInPast = errors.New("expires_at must be in the future")
+
+// GetArchiveRules handles listing the archive rules (admin only)
+func GetArchiveRules(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var rules []models.ArchiveRule
+	if err := db.Preload("Category").Order("id ASC").Find(&rules).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch archive rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"total": len(rules),
+	})
+}
+
+// CreateArchiveRule handles creating an archive rule (admin only)
+func CreateArchiveRule(c *gin.Context) {
+	var req ArchiveRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	rule := models.ArchiveRule{Enabled: true}
+	if !applyArchiveRuleRequest(c, db, &rule, &req) {
+		return
+	}
+
+	if err := db.Create(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create archive rule"})
+		return
+	}
+
+	db.Preload("Category").First(&rule, rule.ID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Archive rule created successfully",
+		"rule":    rule,
+	})
+}
+
+// UpdateArchiveRule handles replacing an archive rule (admin only)
+func UpdateArchiveRule(c *gin.Context) {
+	var req ArchiveRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var rule models.ArchiveRule
+	if err := db.First(&rule, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Archive rule not found"})
+		return
+	}
+
+	if !applyArchiveRuleRequest(c, db, &rule, &req) {
+		return
+	}
+
+	if err := db.Omit("Category").Save(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update archive rule"})
+		return
+	}
+
+	db.Preload("Category").First(&rule, rule.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Archive rule updated successfully",
+		"rule":    rule,
+	})
+}
+
+// DeleteArchiveRule handles deleting an archive rule; posts it archived stay archived (admin only)
+func DeleteArchiveRule(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	result := db.Delete(&models.ArchiveRule{}, c.Param("id"))
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete archive rule"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Archive rule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Archive rule deleted successfully"})
+}
+
+// GetAuditEntries handles listing audit entries, newest first, optionally
+// for one entity or action (admin only)
+func GetAuditEntries(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	p := parsePagination(c)
+
+	query := db.Model(&models.AuditEntry{})
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if entityID := c.Query("entity_id"); entityID != "" {
+		query = query.Where("entity_id = ?", entityID)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit entries"})
+		return
+	}
+
+	var entries []models.AuditEntry
+	if err := query.Order("created_at DESC, id DESC").Offset(p.Offset()).Limit(p.Limit).Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit entries"})
+		return
+	}
+
+	setPaginationHeaders(c, p, total, true)
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"total":   total,
+		"page":    p.Page,
+		"limit":   p.Limit,
+	})
+}
+
+// applyArchiveRuleRequest copies a request onto a rule, checking that its
+// category exists. A category_id of 0 makes the rule apply to all posts.
+func applyArchiveRuleRequest(c *gin.Context, db *gorm.DB, rule *models.ArchiveRule, req *ArchiveRuleRequest) bool {
+	if req.CategoryID != nil && *req.CategoryID == 0 {
+		req.CategoryID = nil
+	}
+	if req.CategoryID != nil {
+		var category models.Category
+		if err := db.First(&category, *req.CategoryID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+			return false
+		}
+	}
+
+	rule.Name = req.Name
+	rule.CategoryID = req.CategoryID
+	rule.MaxAgeMonths = req.MaxAgeMonths
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	return true
+}
+
+// validateExpiresAt checks that a requested expiry time is in the future
+func validateExpiresAt(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errExpiryInPast
+	}
+	return nil
+}
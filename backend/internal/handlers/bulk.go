@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"codewithdell/backend/internal/lint"
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// BulkRequest represents a bulk content operation request
+type BulkRequest struct {
+	IDs         []uint     `json:"ids" binding:"required,min=1,max=500,dive,min=1"`
+	Action      string     `json:"action" binding:"required,oneof=set_status add_tags remove_tags add_categories remove_categories set_author delete"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	TagIDs      []uint     `json:"tag_ids"`
+	CategoryIDs []uint     `json:"category_ids"`
+	AuthorID    uint       `json:"author_id"`
+	DryRun      bool       `json:"dry_run"`
+}
+
+// BulkItemResult is the outcome of a bulk operation for one item
+type BulkItemResult struct {
+	ID     uint   `json:"id"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Per-item results of a bulk operation
+const (
+	bulkResultUpdated   = "updated"
+	bulkResultUnchanged = "unchanged"
+	bulkResultNotFound  = "not_found"
+	bulkResultFailed    = "failed"
+)
+
+// errBulkRollback rolls back a dry run or a bulk operation with failed items
+var errBulkRollback = errors.New("bulk operation rolled back")
+
+// bulkTarget describes a content type that supports bulk operations
+type bulkTarget struct {
+	table string
+	// kind is the type name used for related content caches
+	kind string
+	// owner is the column that refers to the content in join tables
+	owner         string
+	tagTable      string
+	categoryTable string
+	statuses      []string
+	actions       []string
+	// versioned types bump their version on every change
+	versioned bool
+}
+
+// bulkTargets lists the content types of the bulk API by route name
+var bulkTargets = map[string]*bulkTarget{
+	"posts": {
+		table: "posts", kind: "post", owner: "post_id",
+		tagTable: "post_tags", categoryTable: "post_categories",
+		statuses:  []string{"draft", "published", "archived", "scheduled"},
+		actions:   []string{"set_status", "add_tags", "remove_tags", "add_categories", "remove_categories", "set_author", "delete"},
+		versioned: true,
+	},
+	"projects": {
+		table: "projects", kind: "project", owner: "project_id",
+		tagTable: "project_tags", categoryTable: "project_categories",
+		statuses:  []string{"draft", "published", "archived", "scheduled"},
+		actions:   []string{"set_status", "add_tags", "remove_tags", "add_categories", "remove_categories", "set_author", "delete"},
+		versioned: true,
+	},
+	"comments": {
+		table:    "comments",
+		statuses: []string{string(models.CommentStatusPending), string(models.CommentStatusApproved), string(models.CommentStatusSpam)},
+		actions:  []string{"set_status", "delete"},
+	},
+}
+
+// bulkItem is the current state of an item touched by a bulk operation
+type bulkItem struct {
+	Status      string
+	PublishedAt *time.Time
+	AuthorID    uint
+}
+
+// BulkContent handles changing the status, tags, categories or author of
+// many posts, projects or comments, or deleting them, in one transaction.
+// Either every item is changed or none is (admin only).
+func BulkContent(c *gin.Context) {
+	target, ok := bulkTargets[c.Param("type")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid content type. Use posts, projects or comments"})
+		return
+	}
+
+	var req BulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !containsString(target.actions, req.Action) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Action " + req.Action + " is not supported for " + c.Param("type")})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	if !validateBulkRequest(c, db, target, &req) {
+		return
+	}
+
+	// Posts going live are linted first when lint errors block publishing
+	lintPublish := c.GetBool("lint_blocks_publish") && target.table == "posts"
+
+	ids := uniqueIDs(req.IDs)
+	results := make([]BulkItemResult, 0, len(ids))
+	summary := map[string]int{bulkResultUpdated: 0, bulkResultUnchanged: 0, bulkResultNotFound: 0, bulkResultFailed: 0}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			result := BulkItemResult{ID: id, Result: bulkResultUpdated}
+
+			var item bulkItem
+			columns := "status"
+			if target.owner != "" {
+				columns = "status, published_at, author_id"
+			}
+			err := tx.Table(target.table).Select(columns).Where("id = ? AND deleted_at IS NULL", id).Take(&item).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				result.Result = bulkResultNotFound
+			case err != nil:
+				return err
+			default:
+				changed, err := applyBulkAction(tx, target, &req, id, &item, lintPublish)
+				var failure *bulkItemError
+				switch {
+				case errors.As(err, &failure):
+					result.Result = bulkResultFailed
+					result.Error = failure.Error()
+				case err != nil:
+					return err
+				case !changed:
+					result.Result = bulkResultUnchanged
+				case target.versioned:
+					if err := bumpVersion(tx, target.table, id); err != nil {
+						return err
+					}
+				}
+			}
+
+			summary[result.Result]++
+			results = append(results, result)
+		}
+
+		// Nothing is kept on a dry run or when any item could not be changed
+		if req.DryRun || summary[bulkResultNotFound] > 0 || summary[bulkResultFailed] > 0 {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRollback) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply bulk operation"})
+		return
+	}
+
+	applied := err == nil
+	if applied && target.kind != "" {
+		invalidateRelated(c.Request.Context(), target.kind)
+	}
+
+	response := gin.H{
+		"action":  req.Action,
+		"dry_run": req.DryRun,
+		"applied": applied,
+		"results": results,
+		"summary": summary,
+	}
+	if !applied && !req.DryRun {
+		response["error"] = "Some items could not be changed, no changes were made"
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bulkItemError is a failure of a bulk action for a single item
+type bulkItemError struct {
+	err error
+}
+
+func (e *bulkItemError) Error() string {
+	return e.err.Error()
+}
+
+// validateBulkRequest checks the arguments an action needs and that the tags,
+// categories or author it refers to exist
+func validateBulkRequest(c *gin.Context, db *gorm.DB, target *bulkTarget, req *BulkRequest) bool {
+	var (
+		model interface{}
+		ids   []uint
+		label string
+	)
+	switch req.Action {
+	case "set_status":
+		if !containsString(target.statuses, req.Status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status for " + c.Param("type")})
+			return false
+		}
+		return true
+	case "add_tags", "remove_tags":
+		model, ids, label = &models.Tag{}, uniqueIDs(req.TagIDs), "tag_ids"
+	case "add_categories", "remove_categories":
+		model, ids, label = &models.Category{}, uniqueIDs(req.CategoryIDs), "category_ids"
+	case "set_author":
+		if req.AuthorID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "author_id is required"})
+			return false
+		}
+		model, ids, label = &models.User{}, []uint{req.AuthorID}, "author_id"
+	default:
+		return true
+	}
+
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": label + " is required"})
+		return false
+	}
+
+	var count int64
+	if err := db.Model(model).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate bulk operation"})
+		return false
+	}
+	if int(count) != len(ids) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": label + " refers to unknown items"})
+		return false
+	}
+	return true
+}
+
+// applyBulkAction applies the action of a bulk request to one item and
+// reports whether anything changed
+func applyBulkAction(tx *gorm.DB, target *bulkTarget, req *BulkRequest, id uint, item *bulkItem, lintPublish bool) (bool, error) {
+	switch req.Action {
+	case "set_status":
+		return applyBulkStatus(tx, target, req, id, item, lintPublish)
+	case "add_tags":
+		return linkBulkItem(tx, target.tagTable, target.owner, "tag_id", id, uniqueIDs(req.TagIDs))
+	case "remove_tags":
+		return unlinkBulkItem(tx, target.tagTable, target.owner, "tag_id", id, req.TagIDs)
+	case "add_categories":
+		return linkBulkItem(tx, target.categoryTable, target.owner, "category_id", id, uniqueIDs(req.CategoryIDs))
+	case "remove_categories":
+		return unlinkBulkItem(tx, target.categoryTable, target.owner, "category_id", id, req.CategoryIDs)
+	case "set_author":
+		return applyBulkAuthor(tx, target, req.AuthorID, id, item)
+	case "delete":
+		err := tx.Exec("UPDATE "+target.table+" SET deleted_at = NOW() WHERE id = ?", id).Error
+		return err == nil, err
+	}
+	return false, nil
+}
+
+// applyBulkStatus moves an item to the requested status. Posts and projects
+// get their publish time the same way as through their update endpoints, and
+// posts with lint errors fail when lintPublish is set.
+func applyBulkStatus(tx *gorm.DB, target *bulkTarget, req *BulkRequest, id uint, item *bulkItem, lintPublish bool) (bool, error) {
+	updates := map[string]interface{}{"status": req.Status, "updated_at": time.Now()}
+	changed := item.Status != req.Status
+
+	if target.owner != "" {
+		publishedAt, err := resolvePublishedAt(req.Status, req.PublishedAt, item.PublishedAt)
+		if err != nil {
+			return false, &bulkItemError{err: err}
+		}
+		updates["published_at"] = publishedAt
+		if (publishedAt == nil) != (item.PublishedAt == nil) ||
+			(publishedAt != nil && !publishedAt.Equal(*item.PublishedAt)) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+	if lintPublish && publishes(item.Status, req.Status) {
+		if err := lintBulkPost(tx, id); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Table(target.table).Where("id = ?", id).Updates(updates).Error
+}
+
+// lintBulkPost fails a bulk item for a post with lint errors
+func lintBulkPost(tx *gorm.DB, id uint) error {
+	var post models.Post
+	if err := tx.First(&post, id).Error; err != nil {
+		return err
+	}
+	input, err := postLintInput(tx, &post)
+	if err != nil {
+		return err
+	}
+	report, err := lint.Check(input, lintSite{db: tx})
+	if err != nil {
+		return err
+	}
+	if report.HasErrors() {
+		return &bulkItemError{err: fmt.Errorf("post has %d lint errors", report.Errors)}
+	}
+	return nil
+}
+
+// linkBulkItem adds the links from an item to ids that it does not have yet
+func linkBulkItem(tx *gorm.DB, joinTable, owner, column string, id uint, ids []uint) (bool, error) {
+	var linked int64
+	for _, linkID := range ids {
+		result := tx.Exec("INSERT INTO "+joinTable+" ("+owner+", "+column+") VALUES (?, ?) ON CONFLICT DO NOTHING", id, linkID)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		linked += result.RowsAffected
+	}
+	return linked > 0, nil
+}
+
+// unlinkBulkItem removes the links from an item to ids
+func unlinkBulkItem(tx *gorm.DB, joinTable, owner, column string, id uint, ids []uint) (bool, error) {
+	result := tx.Exec("DELETE FROM "+joinTable+" WHERE "+owner+" = ? AND "+column+" IN ?", id, ids)
+	return result.RowsAffected > 0, result.Error
+}
+
+// applyBulkAuthor makes a user the primary author of an item. The previous
+// primary author's credit moves to the new author, who loses any other
+// credit on the item.
+func applyBulkAuthor(tx *gorm.DB, target *bulkTarget, authorID, id uint, item *bulkItem) (bool, error) {
+	if item.AuthorID == authorID {
+		return false, nil
+	}
+
+	if err := tx.Where(target.owner+" = ? AND user_id = ?", id, authorID).Delete(&models.Contributor{}).Error; err != nil {
+		return false, err
+	}
+	result := tx.Model(&models.Contributor{}).
+		Where(target.owner+" = ? AND user_id = ?", id, item.AuthorID).
+		Updates(map[string]interface{}{"user_id": authorID, "role": models.ContributorRoleAuthor})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		contributor := models.Contributor{UserID: authorID, Role: models.ContributorRoleAuthor}
+		contentID := id
+		if target.owner == "post_id" {
+			contributor.PostID = &contentID
+		} else {
+			contributor.ProjectID = &contentID
+		}
+		if err := tx.Omit("User").Create(&contributor).Error; err != nil {
+			return false, err
+		}
+	}
+
+	err := tx.Table(target.table).Where("id = ?", id).
+		Updates(map[string]interface{}{"author_id": authorID, "updated_at": time.Now()}).Error
+	return err == nil, err
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
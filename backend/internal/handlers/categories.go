@@ -1,9 +1,8 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
-	"strings"
 
 	"codewithdell/backend/internal/models"
 
@@ -17,17 +16,20 @@ type CreateCategoryRequest struct {
 	Description string `json:"description" binding:"max=200"`
 	Color       string `json:"color" binding:"max=7"`
 	Icon        string `json:"icon" binding:"max=50"`
+	ParentID    *uint  `json:"parent_id"`
 }
 
-// UpdateCategoryRequest represents category update request
+// UpdateCategoryRequest represents category update request. A parent_id of 0
+// moves the category to the top level.
 type UpdateCategoryRequest struct {
 	Name        string `json:"name" binding:"omitempty,min=2,max=50"`
 	Description string `json:"description" binding:"max=200"`
 	Color       string `json:"color" binding:"max=7"`
 	Icon        string `json:"icon" binding:"max=50"`
+	ParentID    *uint  `json:"parent_id"`
 }
 
-// GetCategories handles getting all categories
+// GetCategories handles getting all categories as a tree
 func GetCategories(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
@@ -37,8 +39,19 @@ func GetCategories(c *gin.Context) {
 		return
 	}
 
+	if err := localizeCategories(c, db, categories); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category translations"})
+		return
+	}
+
+	// Flat listings suit pickers that render the hierarchy themselves
+	result := categories
+	if c.Query("flat") != "true" {
+		result = models.BuildCategoryTree(categories)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"categories": categories,
+		"categories": result,
 		"total":      len(categories),
 	})
 }
@@ -48,15 +61,56 @@ func GetCategoryBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	db := c.MustGet("db").(*gorm.DB)
 
+	locale, defaultLocale := requestLocale(c)
+
 	var category models.Category
-	if err := db.Preload("Posts").Preload("Projects").
-		Where("slug = ?", slug).
-		First(&category).Error; err != nil {
+	findCategory := func(column string, value interface{}) error {
+		return db.Preload("Parent").Preload("Children", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).Preload("Posts", "status = ?", models.PostStatusPublished).
+			Preload("Projects", "status = ?", models.ProjectStatusPublished).
+			Where(column+" = ?", value).
+			First(&category).Error
+	}
+
+	err := findCategory("slug", slug)
+	if err != nil {
+		// A translated slug serves the category in the translation's locale
+		if translation, ok := findTranslationBySlug(db, slugEntityCategory, slug); ok {
+			err = findCategory("id", translation.EntityID)
+			locale = translation.Locale
+		}
+	}
+	if err != nil {
+		// Links to a renamed category redirect to its current slug
+		if redirectFromSlugHistory(c, db, slugEntityCategory, slug, func(id uint) (string, error) {
+			var current models.Category
+			err := db.Select("slug").First(&current, id).Error
+			return current.Slug, err
+		}) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, category)
+	ids := []uint{category.ID}
+	for _, child := range category.Children {
+		ids = append(ids, child.ID)
+	}
+	translations, err := loadTranslations(db, slugEntityCategory, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category translations"})
+		return
+	}
+	localizeCategory(&category, translations[category.ID], locale, defaultLocale)
+	for i := range category.Children {
+		localizeCategory(&category.Children[i], translations[category.Children[i].ID], locale, defaultLocale)
+	}
+	c.Header("Content-Language", category.Locale)
+	setAlternateLinks(c, category.Alternates)
+
+	respondVersioned(c, category.Version, category)
 }
 
 // CreateCategory handles creating a new category (admin only)
@@ -79,6 +133,18 @@ func CreateCategory(c *gin.Context) {
 		return
 	}
 
+	// A parent_id of 0 creates a top level category
+	if req.ParentID != nil && *req.ParentID == 0 {
+		req.ParentID = nil
+	}
+	if req.ParentID != nil {
+		var parent models.Category
+		if err := db.First(&parent, *req.ParentID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent category not found"})
+			return
+		}
+	}
+
 	// Create category
 	category := models.Category{
 		Name:        req.Name,
@@ -86,6 +152,7 @@ func CreateCategory(c *gin.Context) {
 		Description: req.Description,
 		Color:       req.Color,
 		Icon:        req.Icon,
+		ParentID:    req.ParentID,
 	}
 
 	if err := db.Create(&category).Error; err != nil {
@@ -93,7 +160,7 @@ func CreateCategory(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	respondSaved(c, http.StatusCreated, category.Version, gin.H{
 		"message":  "Category created successfully",
 		"category": category,
 	})
@@ -116,6 +183,12 @@ func UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	if !requireIfMatch(c, category.Version) {
+		return
+	}
+
+	previousSlug := category.Slug
+
 	// Check if new name conflicts with existing category
 	if req.Name != "" && req.Name != category.Name {
 		var existingCategory models.Category
@@ -137,13 +210,52 @@ func UpdateCategory(c *gin.Context) {
 	if req.Icon != "" {
 		category.Icon = req.Icon
 	}
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			hierarchy, err := loadCategoryHierarchy(db)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category"})
+				return
+			}
+			if !categoryExists(hierarchy, *req.ParentID) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Parent category not found"})
+				return
+			}
+			// A category cannot be moved below itself
+			if *req.ParentID == category.ID || containsID(models.CategoryDescendantIDs(hierarchy, category.ID), *req.ParentID) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "A category cannot be nested under itself or its descendants"})
+				return
+			}
+			category.ParentID = req.ParentID
+		}
+	}
 
-	if err := db.Save(&category).Error; err != nil {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, &category, category.Version, map[string]interface{}{
+			"name":        category.Name,
+			"slug":        category.Slug,
+			"description": category.Description,
+			"color":       category.Color,
+			"icon":        category.Icon,
+			"parent_id":   category.ParentID,
+		}); err != nil {
+			return err
+		}
+		return recordSlugChange(tx, slugEntityCategory, category.ID, previousSlug, category.Slug)
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "categories", category.ID)
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	category.Version++
+	respondSaved(c, http.StatusOK, category.Version, gin.H{
 		"message":  "Category updated successfully",
 		"category": category,
 	})
@@ -160,6 +272,10 @@ func DeleteCategory(c *gin.Context) {
 		return
 	}
 
+	if !requireIfMatch(c, category.Version) {
+		return
+	}
+
 	// Check if category has associated posts or projects
 	var postCount int64
 	db.Model(&models.Post{}).Joins("JOIN post_categories ON posts.id = post_categories.post_id").
@@ -178,7 +294,22 @@ func DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	if err := db.Delete(&category).Error; err != nil {
+	var childCount int64
+	db.Model(&models.Category{}).Where("parent_id = ?", category.ID).Count(&childCount)
+	if childCount > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":       "Cannot delete category with subcategories",
+			"child_count": childCount,
+		})
+		return
+	}
+
+	err := deleteVersioned(db, &category, category.Version)
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "categories", category.ID)
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
 		return
 	}
@@ -186,7 +317,8 @@ func DeleteCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
 }
 
-// GetCategoryPosts handles getting posts by category
+// GetCategoryPosts handles getting posts by category, optionally including
+// posts from its descendant categories
 func GetCategoryPosts(c *gin.Context) {
 	slug := c.Param("slug")
 	db := c.MustGet("db").(*gorm.DB)
@@ -197,16 +329,35 @@ func GetCategoryPosts(c *gin.Context) {
 		return
 	}
 
+	categoryIDs, err := categoryScope(db, category.ID, c.Query("include_descendants") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category posts"})
+		return
+	}
+
+	// A post filed under several matching categories is listed once
 	var posts []models.Post
 	if err := db.Preload("Author").Preload("Tags").
-		Joins("JOIN post_categories ON posts.id = post_categories.post_id").
-		Where("post_categories.category_id = ? AND posts.status = ?", category.ID, models.PostStatusPublished).
+		Where("posts.id IN (?) AND posts.status = ?",
+			db.Table("post_categories").Select("post_id").Where("category_id IN ?", categoryIDs),
+			models.PostStatusPublished).
 		Order("posts.created_at DESC").
 		Find(&posts).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category posts"})
 		return
 	}
 
+	listed := []models.Category{category}
+	if err := localizeCategories(c, db, listed); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category translations"})
+		return
+	}
+	category = listed[0]
+	if err := localizePosts(c, db, posts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post translations"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"category": category,
 		"posts":    posts,
@@ -214,7 +365,8 @@ func GetCategoryPosts(c *gin.Context) {
 	})
 }
 
-// GetCategoryProjects handles getting projects by category
+// GetCategoryProjects handles getting projects by category, optionally
+// including projects from its descendant categories
 func GetCategoryProjects(c *gin.Context) {
 	slug := c.Param("slug")
 	db := c.MustGet("db").(*gorm.DB)
@@ -225,16 +377,34 @@ func GetCategoryProjects(c *gin.Context) {
 		return
 	}
 
+	categoryIDs, err := categoryScope(db, category.ID, c.Query("include_descendants") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category projects"})
+		return
+	}
+
 	var projects []models.Project
 	if err := db.Preload("Technologies").Preload("Tags").
-		Joins("JOIN project_categories ON projects.id = project_categories.project_id").
-		Where("project_categories.category_id = ? AND projects.status = ?", category.ID, models.ProjectStatusPublished).
+		Where("projects.id IN (?) AND projects.status = ?",
+			db.Table("project_categories").Select("project_id").Where("category_id IN ?", categoryIDs),
+			models.ProjectStatusPublished).
 		Order("projects.created_at DESC").
 		Find(&projects).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category projects"})
 		return
 	}
 
+	listed := []models.Category{category}
+	if err := localizeCategories(c, db, listed); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category translations"})
+		return
+	}
+	category = listed[0]
+	if err := localizeProjects(c, db, projects); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project translations"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"category": category,
 		"projects": projects,
@@ -242,28 +412,42 @@ func GetCategoryProjects(c *gin.Context) {
 	})
 }
 
-// generateSlug generates a URL-friendly slug from a string
-func generateSlug(input string) string {
-	// Convert to lowercase and replace spaces with hyphens
-	slug := strings.ToLower(input)
-	slug = strings.ReplaceAll(slug, " ", "-")
-	
-	// Remove special characters except hyphens
-	var result strings.Builder
-	for _, char := range slug {
-		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-			result.WriteRune(char)
+// loadCategoryHierarchy loads the id and parent of every category
+func loadCategoryHierarchy(db *gorm.DB) ([]models.Category, error) {
+	var categories []models.Category
+	err := db.Select("id", "parent_id").Find(&categories).Error
+	return categories, err
+}
+
+// categoryScope returns the category ids a listing covers: the category
+// itself and, when requested, all of its descendants
+func categoryScope(db *gorm.DB, categoryID uint, includeDescendants bool) ([]uint, error) {
+	ids := []uint{categoryID}
+	if !includeDescendants {
+		return ids, nil
+	}
+
+	hierarchy, err := loadCategoryHierarchy(db)
+	if err != nil {
+		return nil, err
+	}
+	return append(ids, models.CategoryDescendantIDs(hierarchy, categoryID)...), nil
+}
+
+func categoryExists(categories []models.Category, id uint) bool {
+	for _, category := range categories {
+		if category.ID == id {
+			return true
 		}
 	}
-	
-	// Remove multiple consecutive hyphens
-	slug = result.String()
-	for strings.Contains(slug, "--") {
-		slug = strings.ReplaceAll(slug, "--", "-")
-	}
-	
-	// Remove leading and trailing hyphens
-	slug = strings.Trim(slug, "-")
-	
-	return slug
-} 
\ No newline at end of file
+	return false
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"codewithdell/backend/internal/etag"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// errVersionConflict is returned when content changed after its version was checked
+var errVersionConflict = errors.New("content was modified concurrently")
+
+// viewCountField is left out of ETags, as the view count changes on every
+// request that could be answered with 304 Not Modified
+const viewCountField = "view_count"
+
+// respondVersioned writes the JSON response for a version of content. Its
+// ETag covers the whole body but the view count, including related content
+// and the locale, and requests whose If-None-Match header names it get 304
+// Not Modified.
+func respondVersioned(c *gin.Context, version int, body interface{}) {
+	data, tag, ok := encodeVersioned(c, version, body)
+	if !ok {
+		return
+	}
+
+	if header := c.GetHeader("If-None-Match"); header != "" && etag.NoneMatch(header, tag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// respondSaved writes the JSON response of a create or update with the ETag
+// of the saved version, in the same format as respondVersioned
+func respondSaved(c *gin.Context, status int, version int, body interface{}) {
+	data, _, ok := encodeVersioned(c, version, body)
+	if !ok {
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", data)
+}
+
+// encodeVersioned encodes the body of a response for a version of content
+// and sets its ETag and Vary headers. It writes a 500 response and returns
+// false when the body cannot be encoded.
+func encodeVersioned(c *gin.Context, version int, body interface{}) ([]byte, string, bool) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode response"})
+		return nil, "", false
+	}
+
+	digested := data
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil {
+		delete(fields, viewCountField)
+		if stable, err := json.Marshal(fields); err == nil {
+			digested = stable
+		}
+	}
+
+	tag := etag.FormatBody(version, digested)
+	c.Header("ETag", tag)
+	if !headerHasValue(c.Writer.Header().Values("Vary"), "Accept-Language") {
+		c.Writer.Header().Add("Vary", "Accept-Language")
+	}
+	return data, tag, true
+}
+
+// headerHasValue reports whether comma separated header values include value
+func headerHasValue(values []string, value string) bool {
+	for _, line := range values {
+		for _, item := range strings.Split(line, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requireIfMatch checks that the If-Match header of a PUT or DELETE names the
+// current version of the content. It writes a 428 response when the header
+// is missing and a 412 response when the version is stale.
+func requireIfMatch(c *gin.Context, version int) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{
+			"error":   "If-Match header with the current version is required",
+			"version": version,
+		})
+		return false
+	}
+	if !etag.Match(header, version) {
+		respondVersionConflict(c, version)
+		return false
+	}
+	return true
+}
+
+// respondVersionConflict writes a 412 response with the current version
+func respondVersionConflict(c *gin.Context, version int) {
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"error":   "Content was modified by someone else, reload it and try again",
+		"version": version,
+	})
+}
+
+// respondStaleVersion writes a 412 response for a row that was changed by
+// another request between the If-Match check and the write
+func respondStaleVersion(c *gin.Context, db *gorm.DB, table string, id uint) {
+	var version int
+	if err := db.Table(table).Select("version").Where("id = ?", id).Row().Scan(&version); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch current version"})
+		return
+	}
+	respondVersionConflict(c, version)
+}
+
+// updateVersioned applies updates to the row of model and bumps its version,
+// provided the row still has version. It returns errVersionConflict otherwise.
+func updateVersioned(tx *gorm.DB, model interface{}, version int, updates map[string]interface{}) error {
+	updates["version"] = gorm.Expr("version + 1")
+	result := tx.Model(model).Where("version = ?", version).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errVersionConflict
+	}
+	return nil
+}
+
+// bumpVersion bumps the version of a row whose related data changed, such
+// as its translations
+func bumpVersion(tx *gorm.DB, table string, id uint) error {
+	return tx.Table(table).Where("id = ?", id).UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+// deleteVersioned soft deletes the row of model, provided it still has
+// version. It returns errVersionConflict otherwise.
+func deleteVersioned(db *gorm.DB, model interface{}, version int) error {
+	result := db.Where("version = ?", version).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errVersionConflict
+	}
+	return nil
+}
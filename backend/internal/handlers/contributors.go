@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ContributorInput represents a contributor in a post or project request
+type ContributorInput struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"omitempty,oneof=author editor illustrator reviewer"`
+}
+
+var (
+	errContributorsWithoutAuthor = errors.New("contributors must include at least one author")
+	errDuplicateContributor      = errors.New("a user can only be listed once as a contributor")
+	errUnknownContributor        = errors.New("contributor user not found")
+)
+
+// userPostsCondition matches posts a user wrote or contributed to
+const userPostsCondition = "(posts.author_id = ? OR posts.id IN (SELECT post_id FROM contributors WHERE user_id = ? AND post_id IS NOT NULL))"
+
+// userProjectsCondition matches projects a user built or contributed to
+const userProjectsCondition = "(projects.author_id = ? OR projects.id IN (SELECT project_id FROM contributors WHERE user_id = ? AND project_id IS NOT NULL))"
+
+// usernamePostsCondition matches posts written or contributed to by a username
+const usernamePostsCondition = `(posts.author_id IN (SELECT id FROM users WHERE username = ?)
+	OR posts.id IN (SELECT contributors.post_id FROM contributors
+		JOIN users ON users.id = contributors.user_id
+		WHERE users.username = ? AND contributors.post_id IS NOT NULL))`
+
+// usernameProjectsCondition matches projects built or contributed to by a username
+const usernameProjectsCondition = `(projects.author_id IN (SELECT id FROM users WHERE username = ?)
+	OR projects.id IN (SELECT contributors.project_id FROM contributors
+		JOIN users ON users.id = contributors.user_id
+		WHERE users.username = ? AND contributors.project_id IS NOT NULL))`
+
+// orderedContributors preloads contributors sorted by their credit position
+func orderedContributors(db *gorm.DB) *gorm.DB {
+	return db.Order("contributors.position ASC, contributors.id ASC")
+}
+
+// preloadContributors preloads the ordered contributors of posts or projects with their users
+func preloadContributors(db *gorm.DB) *gorm.DB {
+	return db.Preload("Contributors", orderedContributors).Preload("Contributors.User")
+}
+
+// validateContributors checks a contributor list and returns the user id of
+// its primary author, the first contributor with the author role
+func validateContributors(db *gorm.DB, inputs []ContributorInput) (uint, error) {
+	var primaryAuthorID uint
+	seen := make(map[uint]bool, len(inputs))
+	for i := range inputs {
+		if inputs[i].Role == "" {
+			inputs[i].Role = string(models.ContributorRoleAuthor)
+		}
+		if seen[inputs[i].UserID] {
+			return 0, errDuplicateContributor
+		}
+		seen[inputs[i].UserID] = true
+
+		if primaryAuthorID == 0 && inputs[i].Role == string(models.ContributorRoleAuthor) {
+			primaryAuthorID = inputs[i].UserID
+		}
+	}
+	if primaryAuthorID == 0 {
+		return 0, errContributorsWithoutAuthor
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).Where("id IN ?", mapKeys(seen)).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if int(count) != len(seen) {
+		return 0, errUnknownContributor
+	}
+
+	return primaryAuthorID, nil
+}
+
+// replaceContributors replaces the contributors of the post or project whose
+// id is stored in column, keeping the order of inputs
+func replaceContributors(tx *gorm.DB, column string, id uint, inputs []ContributorInput) error {
+	if err := tx.Where(column+" = ?", id).Delete(&models.Contributor{}).Error; err != nil {
+		return err
+	}
+
+	contributors := make([]models.Contributor, 0, len(inputs))
+	for position, input := range inputs {
+		contributor := models.Contributor{
+			UserID:   input.UserID,
+			Role:     models.ContributorRole(input.Role),
+			Position: position,
+		}
+		contentID := id
+		if column == "post_id" {
+			contributor.PostID = &contentID
+		} else {
+			contributor.ProjectID = &contentID
+		}
+		contributors = append(contributors, contributor)
+	}
+	if len(contributors) == 0 {
+		return nil
+	}
+	return tx.Omit("User").Create(&contributors).Error
+}
+
+// respondContributorError writes the response for a failed validateContributors call
+func respondContributorError(c *gin.Context, err error) {
+	if errors.Is(err, errContributorsWithoutAuthor) ||
+		errors.Is(err, errDuplicateContributor) ||
+		errors.Is(err, errUnknownContributor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate contributors"})
+}
+
+func mapKeys(m map[uint]bool) []uint {
+	keys := make([]uint, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"codewithdell/backend/internal/exporter"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ExportSite handles streaming the whole site as a zip archive of Markdown
+// and JSON files, which the import endpoint restores (admin only)
+func ExportSite(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Large sites take longer to stream than the server write timeout allows
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn().Err(err).Msg("Failed to lift write deadline for export")
+	}
+
+	filename := "codewithdell-export-" + time.Now().Format("20060102-150405") + ".zip"
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	// Once streaming has started the status cannot change, so a failure
+	// leaves a truncated archive and is only logged
+	if err := exporter.New(db, "uploads").Write(c.Request.Context(), c.Writer); err != nil {
+		log.Error().Err(err).Msg("Site export failed")
+		c.Abort()
+	}
+}
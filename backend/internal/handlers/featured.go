@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// FeaturedSlotRequest represents a featured slot creation or update request
+type FeaturedSlotRequest struct {
+	Type      string     `json:"type" binding:"required,oneof=post project"`
+	ContentID uint       `json:"content_id" binding:"required,min=1"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+}
+
+// ReorderFeaturedRequest represents a featured rail reorder request
+type ReorderFeaturedRequest struct {
+	SlotIDs []uint `json:"slot_ids" binding:"required,min=1"`
+}
+
+// FeaturedItem is a post or project on the featured rail
+type FeaturedItem struct {
+	SlotID   uint            `json:"slot_id"`
+	Position int             `json:"position"`
+	Type     string          `json:"type"`
+	Post     *models.Post    `json:"post,omitempty"`
+	Project  *models.Project `json:"project,omitempty"`
+}
+
+// errFeaturedWindow is returned when a featured slot ends before it starts
+var errFeaturedWindow = errors.New("ends_at must be after starts_at")
+
+// orderedFeaturedSlots sorts featured slots by their position on the rail
+func orderedFeaturedSlots(db *gorm.DB) *gorm.DB {
+	return db.Order("featured_slots.position ASC, featured_slots.id ASC")
+}
+
+// GetFeatured handles getting the posts and projects currently on the
+// featured rail, in their manual order. Slots outside their dates or whose
+// content is not published are left out.
+func GetFeatured(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	now := time.Now()
+
+	var slots []models.FeaturedSlot
+	if err := orderedFeaturedSlots(db).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Find(&slots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch featured content"})
+		return
+	}
+
+	var postIDs, projectIDs []uint
+	for _, slot := range slots {
+		switch {
+		case slot.PostID != nil:
+			postIDs = append(postIDs, *slot.PostID)
+		case slot.ProjectID != nil:
+			projectIDs = append(projectIDs, *slot.ProjectID)
+		}
+	}
+
+	var posts []models.Post
+	if len(postIDs) > 0 {
+		if err := preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").
+			Where("id IN ? AND status = ?", postIDs, models.PostStatusPublished).
+			Find(&posts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch featured content"})
+			return
+		}
+		if err := localizePosts(c, db, posts); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post translations"})
+			return
+		}
+	}
+
+	var projects []models.Project
+	if len(projectIDs) > 0 {
+		if err := preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").Preload("Technologies").
+			Where("id IN ? AND status = ?", projectIDs, models.ProjectStatusPublished).
+			Find(&projects).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch featured content"})
+			return
+		}
+		if err := localizeProjects(c, db, projects); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project translations"})
+			return
+		}
+	}
+
+	postsByID := make(map[uint]*models.Post, len(posts))
+	for i := range posts {
+		postsByID[posts[i].ID] = &posts[i]
+	}
+	projectsByID := make(map[uint]*models.Project, len(projects))
+	for i := range projects {
+		projectsByID[projects[i].ID] = &projects[i]
+	}
+
+	// The same content featured twice is only shown in its first slot
+	items := make([]FeaturedItem, 0, len(slots))
+	for _, slot := range slots {
+		item := FeaturedItem{SlotID: slot.ID, Position: slot.Position, Type: string(slot.Type)}
+		switch {
+		case slot.PostID != nil && postsByID[*slot.PostID] != nil:
+			item.Post = postsByID[*slot.PostID]
+			delete(postsByID, *slot.PostID)
+		case slot.ProjectID != nil && projectsByID[*slot.ProjectID] != nil:
+			item.Project = projectsByID[*slot.ProjectID]
+			delete(projectsByID, *slot.ProjectID)
+		default:
+			continue
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+// GetFeaturedSlots handles listing every featured slot, including scheduled
+// and ended ones, with its content (admin only)
+func GetFeaturedSlots(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var slots []models.FeaturedSlot
+	if err := orderedFeaturedSlots(db).Preload("Post").Preload("Project").Find(&slots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch featured slots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"slots": slots,
+		"total": len(slots),
+	})
+}
+
+// CreateFeaturedSlot handles adding a post or project to the end of the
+// featured rail (admin only)
+func CreateFeaturedSlot(c *gin.Context) {
+	var req FeaturedSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var slot models.FeaturedSlot
+	if !applyFeaturedSlotRequest(c, db, &slot, &req) {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var maxPosition *int
+		if err := tx.Model(&models.FeaturedSlot{}).Select("MAX(position)").Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+		if maxPosition != nil {
+			slot.Position = *maxPosition + 1
+		}
+		return tx.Omit("Post", "Project").Create(&slot).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create featured slot"})
+		return
+	}
+
+	db.Preload("Post").Preload("Project").First(&slot, slot.ID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Featured slot created successfully",
+		"slot":    slot,
+	})
+}
+
+// UpdateFeaturedSlot handles replacing the content and dates of a featured
+// slot; its position is kept (admin only)
+func UpdateFeaturedSlot(c *gin.Context) {
+	var req FeaturedSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var slot models.FeaturedSlot
+	if err := db.First(&slot, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Featured slot not found"})
+		return
+	}
+
+	if !applyFeaturedSlotRequest(c, db, &slot, &req) {
+		return
+	}
+
+	if err := db.Omit("Post", "Project").Save(&slot).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update featured slot"})
+		return
+	}
+
+	slot.Post, slot.Project = nil, nil
+	db.Preload("Post").Preload("Project").First(&slot, slot.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Featured slot updated successfully",
+		"slot":    slot,
+	})
+}
+
+// DeleteFeaturedSlot handles removing a slot from the featured rail (admin only)
+func DeleteFeaturedSlot(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	result := db.Delete(&models.FeaturedSlot{}, c.Param("id"))
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete featured slot"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Featured slot not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Featured slot deleted successfully"})
+}
+
+// ReorderFeaturedSlots handles reordering the featured rail in one transaction (admin only)
+func ReorderFeaturedSlots(c *gin.Context) {
+	var req ReorderFeaturedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var existingIDs []uint
+	if err := db.Model(&models.FeaturedSlot{}).Pluck("id", &existingIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch featured slots"})
+		return
+	}
+
+	// The new order must list every slot exactly once
+	if !sameIDSet(existingIDs, req.SlotIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slot_ids must contain each featured slot exactly once"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range req.SlotIDs {
+			if err := tx.Model(&models.FeaturedSlot{}).
+				Where("id = ?", id).
+				UpdateColumn("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder featured slots"})
+		return
+	}
+
+	var slots []models.FeaturedSlot
+	orderedFeaturedSlots(db).Preload("Post").Preload("Project").Find(&slots)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Featured slots reordered successfully",
+		"slots":   slots,
+	})
+}
+
+// applyFeaturedSlotRequest copies a request onto a slot, checking that its
+// dates are in order and that the post or project exists
+func applyFeaturedSlotRequest(c *gin.Context, db *gorm.DB, slot *models.FeaturedSlot, req *FeaturedSlotRequest) bool {
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errFeaturedWindow.Error()})
+		return false
+	}
+
+	id := req.ContentID
+	slot.Type = models.FeaturedType(req.Type)
+	slot.PostID, slot.ProjectID = nil, nil
+	slot.StartsAt, slot.EndsAt = req.StartsAt, req.EndsAt
+
+	if slot.Type == models.FeaturedTypePost {
+		if err := db.Select("id").First(&models.Post{}, id).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Post not found"})
+			return false
+		}
+		slot.PostID = &id
+	} else {
+		if err := db.Select("id").First(&models.Project{}, id).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Project not found"})
+			return false
+		}
+		slot.ProjectID = &id
+	}
+	return true
+}
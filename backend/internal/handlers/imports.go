@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"codewithdell/backend/internal/importer"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxImportArchiveSize bounds an uploaded import archive
+const maxImportArchiveSize = 50 * 1024 * 1024
+
+// maxWordPressExportSize bounds an uploaded WordPress export, which is read
+// as a stream
+const maxWordPressExportSize = 200 * 1024 * 1024
+
+// ImportPosts handles importing posts from a zip archive of Markdown files
+// with front matter or from a WordPress export, or restoring a site export
+// (admin only)
+func ImportPosts(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No archive provided"})
+		return
+	}
+	defer file.Close()
+
+	wordpress := strings.EqualFold(filepath.Ext(header.Filename), ".xml")
+	if wordpress && header.Size > maxWordPressExportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Export too large. Maximum size is 200MB"})
+		return
+	}
+	if !wordpress && header.Size > maxImportArchiveSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Archive too large. Maximum size is 50MB"})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	authorID, _ := strconv.ParseUint(c.MustGet("user_id").(string), 10, 32)
+	dryRun := c.Query("dry_run") == "true" || c.PostForm("dry_run") == "true"
+
+	im := importer.New(db, importer.Options{
+		AuthorID:  uint(authorID),
+		UploadDir: "uploads",
+		DryRun:    dryRun,
+	})
+
+	var report *importer.Report
+	if wordpress {
+		// Media is optional and comes as a zip of wp-content/uploads
+		var media fs.FS
+		if mediaFile, mediaHeader, err := c.Request.FormFile("media"); err == nil {
+			defer mediaFile.Close()
+			if mediaHeader.Size > maxImportArchiveSize {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Media archive too large. Maximum size is 50MB"})
+				return
+			}
+			if media, err = importer.OpenZip(mediaFile, mediaHeader.Size); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		report, err = im.RunWordPress(file, media)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read export: " + err.Error()})
+			return
+		}
+	} else {
+		fsys, err := importer.OpenZip(file, header.Size)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		report, err = im.Run(fsys)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read archive: " + err.Error()})
+			return
+		}
+	}
+
+	if report.Created+report.Updated > 0 && !report.DryRun {
+		invalidateRelated(c.Request.Context(), "post")
+		if report.Restored != nil {
+			invalidateRelated(c.Request.Context(), "project")
+		}
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"codewithdell/backend/internal/models"
 
@@ -10,127 +11,186 @@ import (
 	"gorm.io/gorm"
 )
 
-// LikePost handles liking a post
-func LikePost(c *gin.Context) {
-	postID := c.Param("id")
+// InteractionItem represents a liked or bookmarked post or project
+type InteractionItem struct {
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Post      *models.Post    `json:"post,omitempty"`
+	Project   *models.Project `json:"project,omitempty"`
+}
+
+// interactionTarget identifies the content an interaction applies to
+type interactionTarget struct {
+	kind   string
+	label  string
+	column string
+	table  string
+	id     uint
+}
+
+// resolveInteractionTarget reads the content type and ID from the route.
+// It writes the error response and returns false when the target is invalid.
+func resolveInteractionTarget(c *gin.Context) (*interactionTarget, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return nil, false
+	}
+
+	switch c.Param("type") {
+	case "posts":
+		return &interactionTarget{kind: "post", label: "Post", column: "post_id", table: "posts", id: uint(id)}, true
+	case "projects":
+		return &interactionTarget{kind: "project", label: "Project", column: "project_id", table: "projects", id: uint(id)}, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid content type"})
+		return nil, false
+	}
+}
+
+// exists checks that the target post or project exists
+func (t *interactionTarget) exists(db *gorm.DB) bool {
+	var count int64
+	db.Table(t.table).Where("id = ? AND deleted_at IS NULL", t.id).Count(&count)
+	return count > 0
+}
+
+// assign sets the target on a like or bookmark foreign key pair
+func (t *interactionTarget) assign(postID, projectID **uint) {
+	id := t.id
+	if t.kind == "post" {
+		*postID = &id
+	} else {
+		*projectID = &id
+	}
+}
+
+// LikeContent handles liking a post or project
+func LikeContent(c *gin.Context) {
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
-
-	postIDUint, _ := strconv.ParseUint(postID, 10, 32)
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
-	// Check if post exists
-	var post models.Post
-	if err := db.First(&post, postIDUint).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+	// Check if content exists
+	if !target.exists(db) {
+		c.JSON(http.StatusNotFound, gin.H{"error": target.label + " not found"})
 		return
 	}
 
-	// Check if user already liked the post
+	// Check if user already liked the content
 	var existingLike models.Like
-	if err := db.Where("user_id = ? AND post_id = ?", userIDUint, postIDUint).First(&existingLike).Error; err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Post already liked"})
+	if err := db.Where("user_id = ? AND "+target.column+" = ?", userIDUint, target.id).First(&existingLike).Error; err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": target.label + " already liked"})
 		return
 	}
 
-	// Create like
-	like := models.Like{
-		UserID: uint(userIDUint),
-		PostID: &post.ID,
-	}
+	like := models.Like{UserID: uint(userIDUint)}
+	target.assign(&like.PostID, &like.ProjectID)
 
-	if err := db.Create(&like).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like post"})
+	// Create like and increment like count together
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&like).Error; err != nil {
+			return err
+		}
+		return tx.Table(target.table).Where("id = ?", target.id).
+			UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like " + target.kind})
 		return
 	}
 
-	// Increment like count
-	db.Model(&post).UpdateColumn("like_count", post.LikeCount+1)
-
-	c.JSON(http.StatusOK, gin.H{"message": "Post liked successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": target.label + " liked successfully"})
 }
 
-// UnlikePost handles unliking a post
-func UnlikePost(c *gin.Context) {
-	postID := c.Param("id")
+// UnlikeContent handles unliking a post or project
+func UnlikeContent(c *gin.Context) {
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
-
-	postIDUint, _ := strconv.ParseUint(postID, 10, 32)
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
 	// Check if like exists
 	var like models.Like
-	if err := db.Where("user_id = ? AND post_id = ?", userIDUint, postIDUint).First(&like).Error; err != nil {
+	if err := db.Where("user_id = ? AND "+target.column+" = ?", userIDUint, target.id).First(&like).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Like not found"})
 		return
 	}
 
-	// Delete like
-	if err := db.Delete(&like).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlike post"})
+	// Delete like and decrement like count together
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&like).Error; err != nil {
+			return err
+		}
+		return tx.Table(target.table).Where("id = ? AND like_count > 0", target.id).
+			UpdateColumn("like_count", gorm.Expr("like_count - 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlike " + target.kind})
 		return
 	}
 
-	// Decrement like count
-	var post models.Post
-	db.First(&post, postIDUint)
-	if post.LikeCount > 0 {
-		db.Model(&post).UpdateColumn("like_count", post.LikeCount-1)
-	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "Post unliked successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": target.label + " unliked successfully"})
 }
 
-// BookmarkPost handles bookmarking a post
-func BookmarkPost(c *gin.Context) {
-	postID := c.Param("id")
+// BookmarkContent handles bookmarking a post or project
+func BookmarkContent(c *gin.Context) {
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
-
-	postIDUint, _ := strconv.ParseUint(postID, 10, 32)
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
-	// Check if post exists
-	var post models.Post
-	if err := db.First(&post, postIDUint).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+	// Check if content exists
+	if !target.exists(db) {
+		c.JSON(http.StatusNotFound, gin.H{"error": target.label + " not found"})
 		return
 	}
 
-	// Check if user already bookmarked the post
+	// Check if user already bookmarked the content
 	var existingBookmark models.Bookmark
-	if err := db.Where("user_id = ? AND post_id = ?", userIDUint, postIDUint).First(&existingBookmark).Error; err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Post already bookmarked"})
+	if err := db.Where("user_id = ? AND "+target.column+" = ?", userIDUint, target.id).First(&existingBookmark).Error; err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": target.label + " already bookmarked"})
 		return
 	}
 
-	// Create bookmark
-	bookmark := models.Bookmark{
-		UserID: uint(userIDUint),
-		PostID: &post.ID,
-	}
+	bookmark := models.Bookmark{UserID: uint(userIDUint)}
+	target.assign(&bookmark.PostID, &bookmark.ProjectID)
 
 	if err := db.Create(&bookmark).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to bookmark post"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to bookmark " + target.kind})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post bookmarked successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": target.label + " bookmarked successfully"})
 }
 
-// RemoveBookmark handles removing a bookmark
+// RemoveBookmark handles removing a bookmark from a post or project
 func RemoveBookmark(c *gin.Context) {
-	postID := c.Param("id")
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
-
-	postIDUint, _ := strconv.ParseUint(postID, 10, 32)
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
 	// Check if bookmark exists
 	var bookmark models.Bookmark
-	if err := db.Where("user_id = ? AND post_id = ?", userIDUint, postIDUint).First(&bookmark).Error; err != nil {
+	if err := db.Where("user_id = ? AND "+target.column+" = ?", userIDUint, target.id).First(&bookmark).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Bookmark not found"})
 		return
 	}
@@ -144,117 +204,124 @@ func RemoveBookmark(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Bookmark removed successfully"})
 }
 
-// GetUserLikes handles getting user's liked posts
+// GetUserLikes handles getting user's liked posts and projects
 func GetUserLikes(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
 
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
+	query, ok := interactionTypeFilter(c, db.Where("user_id = ?", userIDUint))
+	if !ok {
+		return
+	}
+
 	var likes []models.Like
-	if err := db.Preload("Post").Preload("Post.Author").
-		Where("user_id = ?", userIDUint).
+	if err := query.Preload("Post").Preload("Post.Author").
+		Preload("Project").Preload("Project.Author").Preload("Project.Technologies").
 		Order("created_at DESC").
 		Find(&likes).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user likes"})
 		return
 	}
 
-	var posts []models.Post
+	items := make([]InteractionItem, 0, len(likes))
 	for _, like := range likes {
-		if like.Post != nil {
-			posts = append(posts, *like.Post)
+		if item, ok := newInteractionItem(like.CreatedAt, like.Post, like.Project); ok {
+			items = append(items, item)
 		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"posts": posts,
-		"total": len(posts),
+		"items": items,
+		"total": len(items),
 	})
 }
 
-// GetUserBookmarks handles getting user's bookmarked posts
+// GetUserBookmarks handles getting user's bookmarked posts and projects
 func GetUserBookmarks(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
 
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
+	query, ok := interactionTypeFilter(c, db.Where("user_id = ?", userIDUint))
+	if !ok {
+		return
+	}
+
 	var bookmarks []models.Bookmark
-	if err := db.Preload("Post").Preload("Post.Author").
-		Where("user_id = ?", userIDUint).
+	if err := query.Preload("Post").Preload("Post.Author").
+		Preload("Project").Preload("Project.Author").Preload("Project.Technologies").
 		Order("created_at DESC").
 		Find(&bookmarks).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user bookmarks"})
 		return
 	}
 
-	var posts []models.Post
+	items := make([]InteractionItem, 0, len(bookmarks))
 	for _, bookmark := range bookmarks {
-		if bookmark.Post != nil {
-			posts = append(posts, *bookmark.Post)
+		if item, ok := newInteractionItem(bookmark.CreatedAt, bookmark.Post, bookmark.Project); ok {
+			items = append(items, item)
 		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"posts": posts,
-		"total": len(posts),
+		"items": items,
+		"total": len(items),
 	})
 }
 
-// CheckUserInteraction handles checking if user has interacted with a post
+// CheckUserInteraction handles checking if user has interacted with a post or project
 func CheckUserInteraction(c *gin.Context) {
-	postID := c.Param("id")
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(string)
-
-	postIDUint, _ := strconv.ParseUint(postID, 10, 32)
 	userIDUint, _ := strconv.ParseUint(userID, 10, 32)
 
-	// Check if user liked the post
+	// Check if user liked the content
 	var like models.Like
-	isLiked := db.Where("user_id = ? AND post_id = ?", userIDUint, postIDUint).First(&like).Error == nil
+	isLiked := db.Where("user_id = ? AND "+target.column+" = ?", userIDUint, target.id).First(&like).Error == nil
 
-	// Check if user bookmarked the post
+	// Check if user bookmarked the content
 	var bookmark models.Bookmark
-	isBookmarked := db.Where("user_id = ? AND post_id = ?", userIDUint, postIDUint).First(&bookmark).Error == nil
+	isBookmarked := db.Where("user_id = ? AND "+target.column+" = ?", userIDUint, target.id).First(&bookmark).Error == nil
 
 	c.JSON(http.StatusOK, gin.H{
-		"is_liked":     isLiked,
+		"type":          target.kind,
+		"id":            target.id,
+		"is_liked":      isLiked,
 		"is_bookmarked": isBookmarked,
 	})
 }
 
-// GetPostStats handles getting post interaction statistics
-func GetPostStats(c *gin.Context) {
-	postID := c.Param("id")
-	db := c.MustGet("db").(*gorm.DB)
-
-	postIDUint, _ := strconv.ParseUint(postID, 10, 32)
-
-	var post models.Post
-	if err := db.First(&post, postIDUint).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-		return
+// interactionTypeFilter applies the optional ?type=post|project filter
+func interactionTypeFilter(c *gin.Context, query *gorm.DB) (*gorm.DB, bool) {
+	switch c.Query("type") {
+	case "":
+		return query, true
+	case "post":
+		return query.Where("post_id IS NOT NULL"), true
+	case "project":
+		return query.Where("project_id IS NOT NULL"), true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type. Use post or project"})
+		return nil, false
 	}
+}
 
-	// Count likes
-	var likeCount int64
-	db.Model(&models.Like{}).Where("post_id = ?", postIDUint).Count(&likeCount)
-
-	// Count bookmarks
-	var bookmarkCount int64
-	db.Model(&models.Bookmark{}).Where("post_id = ?", postIDUint).Count(&bookmarkCount)
-
-	// Count comments
-	var commentCount int64
-	db.Model(&models.Comment{}).Where("post_id = ? AND status = ?", postIDUint, models.CommentStatusApproved).Count(&commentCount)
-
-	c.JSON(http.StatusOK, gin.H{
-		"post_id":        post.ID,
-		"view_count":     post.ViewCount,
-		"like_count":     likeCount,
-		"bookmark_count": bookmarkCount,
-		"comment_count":  commentCount,
-	})
-} 
\ No newline at end of file
+// newInteractionItem builds a typed interaction item, skipping deleted content
+func newInteractionItem(createdAt time.Time, post *models.Post, project *models.Project) (InteractionItem, bool) {
+	switch {
+	case post != nil:
+		return InteractionItem{Type: "post", CreatedAt: createdAt, Post: post}, true
+	case project != nil:
+		return InteractionItem{Type: "project", CreatedAt: createdAt, Project: project}, true
+	default:
+		return InteractionItem{}, false
+	}
+}
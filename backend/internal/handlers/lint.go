@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"codewithdell/backend/internal/lint"
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// lintSlugTables maps the kinds of linked content to their tables
+var lintSlugTables = map[string]string{
+	lint.KindPost:     "posts",
+	lint.KindProject:  "projects",
+	lint.KindCategory: "categories",
+	lint.KindTag:      "tags",
+}
+
+// lintSite answers the lookups of the linter from the database and the
+// uploads directory
+type lintSite struct {
+	db *gorm.DB
+}
+
+// MissingSlugs returns the slugs that no content of a kind uses. Old slugs
+// that still redirect count as existing.
+func (s lintSite) MissingSlugs(kind string, slugs []string) ([]string, error) {
+	var found []string
+	if err := s.db.Table(lintSlugTables[kind]).
+		Where("slug IN ? AND deleted_at IS NULL", slugs).
+		Pluck("slug", &found).Error; err != nil {
+		return nil, err
+	}
+
+	var renamed []string
+	if err := s.db.Model(&models.SlugHistory{}).
+		Where("entity_type = ? AND slug IN ?", kind, slugs).
+		Pluck("slug", &renamed).Error; err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, slug := range slugs {
+		if !containsString(found, slug) && !containsString(renamed, slug) {
+			missing = append(missing, slug)
+		}
+	}
+	return missing, nil
+}
+
+// UploadExists reports whether an /uploads path is a file in the uploads directory
+func (lintSite) UploadExists(path string) bool {
+	info, err := os.Stat(filepath.FromSlash(strings.TrimPrefix(path, "/")))
+	return err == nil && !info.IsDir()
+}
+
+// LintPost handles getting the lint report of a post (admin only)
+func LintPost(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var post models.Post
+	if err := db.First(&post, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	input, err := postLintInput(db, &post)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to lint post"})
+		return
+	}
+	report, err := lint.Check(input, lintSite{db: db})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to lint post"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post_id":         post.ID,
+		"report":          report,
+		"publish_blocked": c.GetBool("lint_blocks_publish") && report.HasErrors(),
+	})
+}
+
+// postLintInput returns the stored content of a post for the linter
+func postLintInput(db *gorm.DB, post *models.Post) (lint.Post, error) {
+	input := lint.Post{Title: post.Title, Excerpt: post.Excerpt, Content: post.Content}
+
+	tags := db.Model(post).Association("Tags")
+	input.Tags = int(tags.Count())
+	if tags.Error != nil {
+		return input, tags.Error
+	}
+	categories := db.Model(post).Association("Categories")
+	input.Categories = int(categories.Count())
+	return input, categories.Error
+}
+
+// publishes reports whether moving a post from one status to another
+// publishes it now or schedules it to be published
+func publishes(from, to string) bool {
+	return from != to && (to == string(models.PostStatusPublished) || to == string(models.PostStatusScheduled))
+}
+
+// publishLintAllowed lints a post that is about to be published. It writes a
+// 422 response with the report and returns false when the post has errors.
+func publishLintAllowed(c *gin.Context, db *gorm.DB, input lint.Post) bool {
+	report, err := lint.Check(input, lintSite{db: db})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to lint post"})
+		return false
+	}
+	if report.HasErrors() {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "Post has lint errors, fix them before publishing",
+			"report": report,
+		})
+		return false
+	}
+	return true
+}
+
+// requestTaxonomy counts the tags and categories a create or update
+// request assigns to a post, when it assigns them
+func requestTaxonomy(db *gorm.DB, input *lint.Post, tagIDs []string, categoryIDs []uint) error {
+	var count int64
+	if tagIDs != nil {
+		if len(tagIDs) > 0 {
+			if err := db.Model(&models.Tag{}).Where("id IN ?", tagIDs).Count(&count).Error; err != nil {
+				return err
+			}
+		}
+		input.Tags = int(count)
+	}
+	if categoryIDs != nil {
+		count = 0
+		if len(categoryIDs) > 0 {
+			if err := db.Model(&models.Category{}).Where("id IN ?", categoryIDs).Count(&count).Error; err != nil {
+				return err
+			}
+		}
+		input.Categories = int(count)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 50
+)
+
+// Pagination holds page based pagination parameters
+type Pagination struct {
+	Page  int
+	Limit int
+}
+
+// Offset returns the row offset for the current page
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// Pages returns the number of pages for a total row count
+func (p Pagination) Pages(total int64) int {
+	pages := int((total + int64(p.Limit) - 1) / int64(p.Limit))
+	if pages <= 0 {
+		pages = 1
+	}
+	return pages
+}
+
+// parsePagination reads page and limit query parameters with defaults
+func parsePagination(c *gin.Context) Pagination {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return Pagination{Page: page, Limit: limit}
+}
+
+// setPaginationHeaders writes the pagination headers exposed by the CORS middleware
+func setPaginationHeaders(c *gin.Context, p Pagination, total int64, includePage bool) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.Header("X-Per-Page", strconv.Itoa(p.Limit))
+	if includePage {
+		c.Header("X-Page-Count", strconv.Itoa(p.Pages(total)))
+		c.Header("X-Current-Page", strconv.Itoa(p.Page))
+	}
+}
+
+// listCursor is the position of the last row of a keyset-paginated page
+type listCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// encodeCursor serializes a cursor into an opaque URL-safe token
+func encodeCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token produced by encodeCursor
+func decodeCursor(token string) (listCursor, error) {
+	var cursor listCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return cursor, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"codewithdell/backend/internal/lint"
+	"codewithdell/backend/internal/markdown"
 	"codewithdell/backend/internal/models"
 
 	"github.com/gin-gonic/gin"
@@ -14,52 +18,279 @@ import (
 
 // CreatePostRequest represents post creation request
 type CreatePostRequest struct {
-	Title       string   `json:"title" binding:"required,min=3"`
-	Content     string   `json:"content" binding:"required,min=10"`
-	Excerpt     string   `json:"excerpt"`
-	Slug        string   `json:"slug"`
-	Status      string   `json:"status" binding:"required,oneof=draft published archived"`
-	TagIDs      []string `json:"tag_ids"`
+	Title        string             `json:"title" binding:"required,min=3"`
+	Content      string             `json:"content" binding:"required,min=10"`
+	Excerpt      string             `json:"excerpt"`
+	Slug         string             `json:"slug"`
+	Status       string             `json:"status" binding:"required,oneof=draft published archived scheduled"`
+	PublishedAt  *time.Time         `json:"published_at"`
+	ExpiresAt    *time.Time         `json:"expires_at"`
+	CategoryIDs  []uint             `json:"category_ids"`
+	TagIDs       []string           `json:"tag_ids"`
+	Contributors []ContributorInput `json:"contributors" binding:"omitempty,dive"`
 }
 
 // UpdatePostRequest represents post update request
 type UpdatePostRequest struct {
-	Title       string   `json:"title"`
-	Content     string   `json:"content"`
-	Excerpt     string   `json:"excerpt"`
-	Slug        string   `json:"slug"`
-	Status      string   `json:"status" binding:"omitempty,oneof=draft published archived"`
-	CategoryID  string   `json:"category_id"`
-	TagIDs      []string `json:"tag_ids"`
-	IsPublished bool     `json:"is_published"`
+	Title        string             `json:"title"`
+	Content      string             `json:"content"`
+	Excerpt      string             `json:"excerpt"`
+	Slug         string             `json:"slug"`
+	Status       string             `json:"status" binding:"omitempty,oneof=draft published archived scheduled"`
+	PublishedAt  *time.Time         `json:"published_at"`
+	ExpiresAt    *time.Time         `json:"expires_at"`
+	RemoveExpiry bool               `json:"remove_expiry"`
+	CategoryIDs  []uint             `json:"category_ids"`
+	TagIDs       []string           `json:"tag_ids"`
+	Contributors []ContributorInput `json:"contributors" binding:"omitempty,dive"`
+}
+
+// PostDetail is a single post with its series navigation and, on request,
+// its Markdown content rendered to HTML. Archived posts stay reachable by
+// slug and are flagged as such, as are expired posts the archiver has not
+// reached yet.
+type PostDetail struct {
+	models.Post
+	Archived    bool                `json:"archived"`
+	ContentHTML string              `json:"content_html,omitempty"`
+	TOC         []markdown.TOCEntry `json:"toc,omitempty"`
+	Series      *SeriesNavigation   `json:"series"`
+}
+
+// postSort describes a supported ordering for post listings
+type postSort struct {
+	column string
+	desc   bool
+	value  func(post *models.Post) string
+	parse  func(value string) (interface{}, error)
+}
+
+func parseCursorTime(value string) (interface{}, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func parseCursorInt(value string) (interface{}, error) {
+	return strconv.Atoi(value)
+}
+
+func parseCursorString(value string) (interface{}, error) {
+	return value, nil
+}
+
+// postSorts lists the sort orders accepted by GetPosts
+var postSorts = map[string]postSort{
+	"newest": {
+		column: "posts.created_at", desc: true,
+		value: func(p *models.Post) string { return p.CreatedAt.Format(time.RFC3339Nano) },
+		parse: parseCursorTime,
+	},
+	"oldest": {
+		column: "posts.created_at", desc: false,
+		value: func(p *models.Post) string { return p.CreatedAt.Format(time.RFC3339Nano) },
+		parse: parseCursorTime,
+	},
+	"popular": {
+		column: "posts.view_count", desc: true,
+		value: func(p *models.Post) string { return strconv.Itoa(p.ViewCount) },
+		parse: parseCursorInt,
+	},
+	"most_liked": {
+		column: "posts.like_count", desc: true,
+		value: func(p *models.Post) string { return strconv.Itoa(p.LikeCount) },
+		parse: parseCursorInt,
+	},
+	"most_commented": {
+		column: "posts.comment_count", desc: true,
+		value: func(p *models.Post) string { return strconv.Itoa(p.CommentCount) },
+		parse: parseCursorInt,
+	},
+	"title": {
+		column: "posts.title", desc: false,
+		value: func(p *models.Post) string { return p.Title },
+		parse: parseCursorString,
+	},
 }
 
 // GetPosts handles getting all posts with pagination and filters
 func GetPosts(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
+	pagination := parsePagination(c)
+
+	sortName := c.DefaultQuery("sort", "newest")
+	sort, ok := postSorts[sortName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort. Use newest, oldest, popular, most_liked, most_commented or title"})
+		return
+	}
+
+	query := db.Model(&models.Post{})
+
+	// Only admins may list unpublished posts
+	switch status := c.DefaultQuery("status", string(models.PostStatusPublished)); status {
+	case string(models.PostStatusPublished):
+		query = query.Where("posts.status = ?", models.PostStatusPublished)
+	case string(models.PostStatusDraft), string(models.PostStatusArchived), string(models.PostStatusScheduled), "all":
+		if c.GetString("role") != string(models.RoleAdmin) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can list unpublished posts"})
+			return
+		}
+		if status != "all" {
+			query = query.Where("posts.status = ?", status)
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	if categories := splitQueryList(c.QueryArray("category")); len(categories) > 0 {
+		query = query.Where(`posts.id IN (
+			SELECT post_categories.post_id FROM post_categories
+			JOIN categories ON categories.id = post_categories.category_id
+			WHERE categories.slug IN ?)`, categories)
+	}
+
+	if tags := splitQueryList(append(c.QueryArray("tag"), c.QueryArray("tags")...)); len(tags) > 0 {
+		query = query.Where(`posts.id IN (
+			SELECT post_tags.post_id FROM post_tags
+			JOIN tags ON tags.id = post_tags.tag_id
+			WHERE tags.slug IN ?)`, tags)
+	}
+
+	// Co-authored posts are listed for each of their contributors
+	if author := c.Query("author"); author != "" {
+		query = query.Where(usernamePostsCondition, author, author)
+	}
+
+	// Date range applies to the publication date, falling back to creation date
+	if from := c.Query("from"); from != "" {
+		fromTime, err := parseDateParam(from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date. Use YYYY-MM-DD or RFC3339"})
+			return
+		}
+		query = query.Where("COALESCE(posts.published_at, posts.created_at) >= ?", fromTime)
+	}
+	if to := c.Query("to"); to != "" {
+		toTime, err := parseDateParam(to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date. Use YYYY-MM-DD or RFC3339"})
+			return
+		}
+		// A bare date includes the whole day
+		if len(to) == len("2006-01-02") {
+			toTime = toTime.AddDate(0, 0, 1)
+		}
+		query = query.Where("COALESCE(posts.published_at, posts.created_at) < ?", toTime)
+	}
+
+	// Reuse the filtered query for both the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch posts"})
+		return
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.desc {
+		direction, comparison = "DESC", "<"
+	}
+	page := preloadContributors(query).Preload("Author").Preload("Categories").Preload("Tags").
+		Order(sort.column + " " + direction).
+		Order("posts.id " + direction).
+		Limit(pagination.Limit)
+
+	// Cursor pagination continues after the last row of the previous page
+	token, useCursor := c.GetQuery("cursor")
+	if useCursor && token != "" {
+		cursor, err := decodeCursor(token)
+		if err != nil || cursor.Sort != sortName {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		value, err := sort.parse(cursor.Value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		page = page.Where("("+sort.column+", posts.id) "+comparison+" (?, ?)", value, cursor.ID)
+	} else if !useCursor {
+		page = page.Offset(pagination.Offset())
+	}
 
-	// Simple query without any filters
 	var posts []models.Post
-	if err := db.Find(&posts).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch posts: " + err.Error()})
+	if err := page.Find(&posts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch posts"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"posts": posts,
-		"total": len(posts),
-	})
+		"total": total,
+		"limit": pagination.Limit,
+	}
+	if len(posts) == pagination.Limit {
+		last := &posts[len(posts)-1]
+		response["next_cursor"] = encodeCursor(listCursor{Sort: sortName, Value: sort.value(last), ID: last.ID})
+	}
+
+	// Cursors refer to the stored values, so posts are localized afterwards
+	if err := localizePosts(c, db, posts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post translations"})
+		return
+	}
+	if !useCursor {
+		response["page"] = pagination.Page
+		response["pages"] = pagination.Pages(total)
+	}
+
+	setPaginationHeaders(c, pagination, total, !useCursor)
+	c.JSON(http.StatusOK, response)
 }
 
+// parseDateParam parses a YYYY-MM-DD or RFC3339 query parameter
+func parseDateParam(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// publicPostStatuses are the statuses of posts reachable by slug
+var publicPostStatuses = []models.PostStatus{models.PostStatusPublished, models.PostStatusArchived}
+
 // GetPostBySlug handles getting a single post by slug
 func GetPostBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	db := c.MustGet("db").(*gorm.DB)
 
+	locale, defaultLocale := requestLocale(c)
+
 	var post models.Post
-	if err := db.Preload("Author").Preload("Tags").
-		Where("slug = ? AND status = ?", slug, models.PostStatusPublished).
-		First(&post).Error; err != nil {
+	findPost := func(column string, value interface{}) error {
+		return preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").
+			Where(column+" = ? AND status IN ?", value, publicPostStatuses).
+			First(&post).Error
+	}
+
+	err := findPost("slug", slug)
+	if err != nil {
+		// A translated slug serves the post in the translation's locale
+		if translation, ok := findTranslationBySlug(db, slugEntityPost, slug); ok {
+			err = findPost("id", translation.EntityID)
+			locale = translation.Locale
+		}
+	}
+	if err != nil {
+		// Links to a renamed post redirect to its current slug
+		if redirectFromSlugHistory(c, db, slugEntityPost, slug, func(id uint) (string, error) {
+			var current models.Post
+			err := db.Select("slug").Where("id = ? AND status IN ?", id, publicPostStatuses).First(&current).Error
+			return current.Slug, err
+		}) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
 		return
 	}
@@ -67,7 +298,41 @@ func GetPostBySlug(c *gin.Context) {
 	// Increment view count
 	db.Model(&post).UpdateColumn("view_count", post.ViewCount+1)
 
-	c.JSON(http.StatusOK, post)
+	translations, err := loadTranslations(db, slugEntityPost, []uint{post.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post translations"})
+		return
+	}
+	translation := localizePost(&post, translations[post.ID], locale, defaultLocale)
+	c.Header("Content-Language", post.Locale)
+	setAlternateLinks(c, post.Alternates)
+
+	detail := PostDetail{Post: post, Archived: post.Status == models.PostStatusArchived || post.IsExpired(time.Now())}
+
+	nav, err := seriesNavigation(db, &post)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch series navigation"})
+		return
+	}
+	detail.Series = nav
+
+	// Include the rendered content alongside the Markdown source
+	if c.Query("render") == "html" {
+		var doc *markdown.Document
+		if translation != nil && translation.Content != "" {
+			doc, err = renderTranslationContent(c.Request.Context(), translation)
+		} else {
+			doc, err = renderPostContent(c.Request.Context(), &post)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render post content"})
+			return
+		}
+		detail.ContentHTML = doc.HTML
+		detail.TOC = doc.TOC
+	}
+
+	respondVersioned(c, post.Version, detail)
 }
 
 // CreatePost handles creating a new post (admin only)
@@ -105,37 +370,93 @@ func CreatePost(c *gin.Context) {
 		status = models.PostStatusPublished
 	case "archived":
 		status = models.PostStatusArchived
+	case "scheduled":
+		status = models.PostStatusScheduled
 	default:
 		status = models.PostStatusDraft
 	}
 
+	publishedAt, err := resolvePublishedAt(req.Status, req.PublishedAt, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateExpiresAt(req.ExpiresAt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The creator is the sole author unless contributors are given
+	contributors := req.Contributors
+	if contributors == nil {
+		contributors = []ContributorInput{{UserID: uint(authorID), Role: string(models.ContributorRoleAuthor)}}
+	}
+	primaryAuthorID, err := validateContributors(db, contributors)
+	if err != nil {
+		respondContributorError(c, err)
+		return
+	}
+
+	// Reading metrics and a missing excerpt are derived from the content
+	summary := markdown.Analyze(req.Content)
+	if req.Excerpt == "" {
+		req.Excerpt = summary.Excerpt
+	}
+
+	// Posts going live are linted first when lint errors block publishing
+	if c.GetBool("lint_blocks_publish") && publishes("", req.Status) {
+		input := lint.Post{Title: req.Title, Excerpt: req.Excerpt, Content: req.Content}
+		if err := requestTaxonomy(db, &input, req.TagIDs, req.CategoryIDs); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to lint post"})
+			return
+		}
+		if !publishLintAllowed(c, db, input) {
+			return
+		}
+	}
+
 	// Create post
 	post := models.Post{
-		Title:    req.Title,
-		Content:  req.Content,
-		Excerpt:  req.Excerpt,
-		Slug:     req.Slug,
-		Status:   status,
-		AuthorID: uint(authorID),
+		Title:       req.Title,
+		Content:     req.Content,
+		Excerpt:     req.Excerpt,
+		Slug:        req.Slug,
+		Status:      status,
+		PublishedAt: publishedAt,
+		ExpiresAt:   req.ExpiresAt,
+		AuthorID:    primaryAuthorID,
+		WordCount:   summary.WordCount,
+		ReadingTime: summary.ReadingTime,
 	}
 
-	if err := db.Create(&post).Error; err != nil {
+	// The first revision is recorded together with the post
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&post).Error; err != nil {
+			return err
+		}
+		if err := replaceContributors(tx, "post_id", post.ID, contributors); err != nil {
+			return err
+		}
+		if err := replacePostCategories(tx, &post, req.CategoryIDs); err != nil {
+			return err
+		}
+		if err := replacePostTags(tx, &post, req.TagIDs); err != nil {
+			return err
+		}
+		_, err := recordPostRevision(tx, &post, uint(authorID))
+		return err
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create post"})
 		return
 	}
 
-	// Add tags if provided
-	if len(req.TagIDs) > 0 {
-		var tags []models.Tag
-		if err := db.Where("id IN ?", req.TagIDs).Find(&tags).Error; err == nil {
-			db.Model(&post).Association("Tags").Append(tags)
-		}
-	}
+	invalidateRelated(c.Request.Context(), "post")
 
 	// Load relationships
-	db.Preload("Author").Preload("Tags").First(&post, post.ID)
+	preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").First(&post, post.ID)
 
-	c.JSON(http.StatusCreated, post)
+	respondSaved(c, http.StatusCreated, post.Version, post)
 }
 
 // UpdatePost handles updating a post (admin only)
@@ -156,6 +477,10 @@ func UpdatePost(c *gin.Context) {
 		return
 	}
 
+	if !requireIfMatch(c, post.Version) {
+		return
+	}
+
 	// Update fields
 	updates := make(map[string]interface{})
 	if req.Title != "" {
@@ -167,34 +492,139 @@ func UpdatePost(c *gin.Context) {
 	if req.Excerpt != "" {
 		updates["excerpt"] = req.Excerpt
 	}
-	if req.Slug != "" {
+	if req.Content != "" {
+		for column, value := range contentMetricUpdates(&post, req.Content, req.Excerpt) {
+			updates[column] = value
+		}
+	}
+	if req.Slug != "" && req.Slug != post.Slug {
+		var existingPost models.Post
+		if err := db.Where("slug = ? AND id <> ?", req.Slug, post.ID).First(&existingPost).Error; err == nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Post with this slug already exists"})
+			return
+		}
 		updates["slug"] = req.Slug
 	}
-	if req.Status != "" {
-		updates["status"] = req.Status
+	if req.Status != "" || req.PublishedAt != nil {
+		status := req.Status
+		if status == "" {
+			status = string(post.Status)
+		}
+		publishedAt, err := resolvePublishedAt(status, req.PublishedAt, post.PublishedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		updates["status"] = status
+		updates["published_at"] = publishedAt
 	}
-	if req.CategoryID != "" {
-		updates["category_id"] = req.CategoryID
+	if req.RemoveExpiry {
+		updates["expires_at"] = nil
+	} else if req.ExpiresAt != nil {
+		if err := validateExpiresAt(req.ExpiresAt); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		updates["expires_at"] = req.ExpiresAt
+	}
+	if req.Contributors != nil {
+		primaryAuthorID, err := validateContributors(db, req.Contributors)
+		if err != nil {
+			respondContributorError(c, err)
+			return
+		}
+		updates["author_id"] = primaryAuthorID
+	}
+
+	// Posts going live are linted with their changes applied when lint
+	// errors block publishing
+	status, _ := updates["status"].(string)
+	if c.GetBool("lint_blocks_publish") && publishes(string(post.Status), status) {
+		input, err := postLintInput(db, &post)
+		if err == nil {
+			err = requestTaxonomy(db, &input, req.TagIDs, req.CategoryIDs)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to lint post"})
+			return
+		}
+		if title, ok := updates["title"].(string); ok {
+			input.Title = title
+		}
+		if content, ok := updates["content"].(string); ok {
+			input.Content = content
+		}
+		if excerpt, ok := updates["excerpt"].(string); ok {
+			input.Excerpt = excerpt
+		}
+		if !publishLintAllowed(c, db, input) {
+			return
+		}
 	}
-	updates["is_published"] = req.IsPublished
 
-	if err := db.Model(&post).Updates(updates).Error; err != nil {
+	// Title, excerpt and content changes are kept as a new revision
+	contentChanged := (req.Title != "" && req.Title != post.Title) ||
+		(req.Content != "" && req.Content != post.Content) ||
+		(req.Excerpt != "" && req.Excerpt != post.Excerpt)
+
+	previousSlug := post.Slug
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if contentChanged {
+			if err := ensureBaselineRevision(tx, &post); err != nil {
+				return err
+			}
+		}
+		if updates["slug"] != nil {
+			if err := recordSlugChange(tx, slugEntityPost, post.ID, previousSlug, req.Slug); err != nil {
+				return err
+			}
+		}
+		// The version is bumped even when only associations change
+		if err := updateVersioned(tx, &post, post.Version, updates); err != nil {
+			return err
+		}
+		if req.Contributors != nil {
+			if err := replaceContributors(tx, "post_id", post.ID, req.Contributors); err != nil {
+				return err
+			}
+		}
+		if err := replacePostCategories(tx, &post, req.CategoryIDs); err != nil {
+			return err
+		}
+		if err := replacePostTags(tx, &post, req.TagIDs); err != nil {
+			return err
+		}
+		if contentChanged {
+			if err := tx.First(&post, post.ID).Error; err != nil {
+				return err
+			}
+			editorID, _ := strconv.ParseUint(c.MustGet("user_id").(string), 10, 32)
+			if _, err := recordPostRevision(tx, &post, uint(editorID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "posts", post.ID)
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update post"})
 		return
 	}
 
-	// Update tags if provided
-	if len(req.TagIDs) > 0 {
-		var tags []models.Tag
-		if err := db.Where("id IN ?", req.TagIDs).Find(&tags).Error; err == nil {
-			db.Model(&post).Association("Tags").Replace(tags)
-		}
+	if req.Content != "" {
+		invalidatePostContent(c.Request.Context(), post.ID)
 	}
 
+	invalidateRelated(c.Request.Context(), "post")
+
 	// Load relationships
-	db.Preload("Author").Preload("Tags").First(&post, post.ID)
+	preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").First(&post, post.ID)
 
-	c.JSON(http.StatusOK, post)
+	respondSaved(c, http.StatusOK, post.Version, post)
 }
 
 // DeletePost handles deleting a post (admin only)
@@ -209,15 +639,58 @@ func DeletePost(c *gin.Context) {
 		return
 	}
 
+	if !requireIfMatch(c, post.Version) {
+		return
+	}
+
 	// Soft delete
-	if err := db.Delete(&post).Error; err != nil {
+	err := deleteVersioned(db, &post, post.Version)
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "posts", post.ID)
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
 		return
 	}
 
+	invalidateRelated(c.Request.Context(), "post")
+
 	c.JSON(http.StatusOK, gin.H{"message": "Post deleted successfully"})
 }
 
+// replacePostCategories replaces the categories of a post. A nil slice leaves
+// them untouched.
+func replacePostCategories(tx *gorm.DB, post *models.Post, categoryIDs []uint) error {
+	if categoryIDs == nil {
+		return nil
+	}
+
+	var categories []models.Category
+	if len(categoryIDs) > 0 {
+		if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(post).Association("Categories").Replace(categories)
+}
+
+// replacePostTags replaces the tags of a post. A nil slice leaves them
+// untouched.
+func replacePostTags(tx *gorm.DB, post *models.Post, tagIDs []string) error {
+	if tagIDs == nil {
+		return nil
+	}
+
+	var tags []models.Tag
+	if len(tagIDs) > 0 {
+		if err := tx.Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(post).Association("Tags").Replace(tags)
+}
+
 // generateSlug generates a URL-friendly slug from title
 func generateSlug(title string) string {
 	// Simple slug generation - in production, use a proper slug library
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPreviewHours = 72
+	maxPreviewHours     = 30 * 24
+)
+
+// CreatePreviewRequest represents a preview link request
+type CreatePreviewRequest struct {
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1"`
+	Note           string `json:"note" binding:"max=200"`
+}
+
+// CreatePreview handles minting a time-limited preview token for a post or project (admin only)
+func CreatePreview(c *gin.Context) {
+	var req CreatePreviewRequest
+	// The body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(string)
+	createdByID, _ := strconv.ParseUint(userID, 10, 32)
+
+	if !target.exists(db) {
+		c.JSON(http.StatusNotFound, gin.H{"error": target.label + " not found"})
+		return
+	}
+
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		hours = defaultPreviewHours
+	}
+	if hours > maxPreviewHours {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_hours must be at most " + strconv.Itoa(maxPreviewHours)})
+		return
+	}
+
+	token, err := generatePreviewToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate preview token"})
+		return
+	}
+
+	preview := models.PreviewToken{
+		ContentType: target.kind,
+		ContentID:   target.id,
+		TokenHash:   hashPreviewToken(token),
+		Note:        req.Note,
+		ExpiresAt:   time.Now().Add(time.Duration(hours) * time.Hour),
+		CreatedByID: uint(createdByID),
+	}
+
+	if err := db.Omit("CreatedBy").Create(&preview).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create preview token"})
+		return
+	}
+
+	// The token is only shown once; it cannot be recovered from its hash
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Preview link created successfully",
+		"token":   token,
+		"url":     "/api/v1/preview/" + token,
+		"preview": preview,
+	})
+}
+
+// GetPreviews handles listing the preview tokens of a post or project (admin only)
+func GetPreviews(c *gin.Context) {
+	target, ok := resolveInteractionTarget(c)
+	if !ok {
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var previews []models.PreviewToken
+	if err := db.Preload("CreatedBy").
+		Where("content_type = ? AND content_id = ?", target.kind, target.id).
+		Order("created_at DESC").
+		Find(&previews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch preview tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"previews": previews,
+		"total":    len(previews),
+	})
+}
+
+// RevokePreview handles revoking a preview token before it expires (admin only)
+func RevokePreview(c *gin.Context) {
+	previewID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var preview models.PreviewToken
+	if err := db.First(&preview, previewID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Preview token not found"})
+		return
+	}
+
+	if preview.RevokedAt == nil {
+		now := time.Now()
+		if err := db.Model(&preview).Update("revoked_at", &now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke preview token"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preview token revoked successfully"})
+}
+
+// GetPreview handles rendering a post or project of any status for the holder of a valid preview token
+func GetPreview(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Previews are private to the link holder
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+
+	var preview models.PreviewToken
+	if err := db.Where("token_hash = ?", hashPreviewToken(c.Param("token"))).First(&preview).Error; err != nil || !preview.IsActive() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Preview link is invalid or has expired"})
+		return
+	}
+
+	db.Model(&preview).UpdateColumns(map[string]interface{}{
+		"view_count":   gorm.Expr("view_count + ?", 1),
+		"last_used_at": time.Now(),
+	})
+
+	switch preview.ContentType {
+	case "post":
+		var post models.Post
+		if err := preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").
+			First(&post, preview.ContentID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+
+		doc, err := renderPostContent(c.Request.Context(), &post)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render post content"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"type":       "post",
+			"expires_at": preview.ExpiresAt,
+			"post": PostDetail{
+				Post:        post,
+				ContentHTML: doc.HTML,
+				TOC:         doc.TOC,
+			},
+		})
+	case "project":
+		var project models.Project
+		if err := preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").Preload("Technologies").
+			Preload("Screenshots", orderedScreenshots).
+			First(&project, preview.ContentID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			return
+		}
+
+		doc, err := renderProjectContent(c.Request.Context(), &project)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render project content"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"type":       "project",
+			"expires_at": preview.ExpiresAt,
+			"project": ProjectDetail{
+				Project:     project,
+				ContentHTML: doc.HTML,
+				TOC:         doc.TOC,
+			},
+		})
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Preview link is invalid or has expired"})
+	}
+}
+
+// generatePreviewToken returns a random URL-safe token
+func generatePreviewToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashPreviewToken returns the stored form of a preview token
+func hashPreviewToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
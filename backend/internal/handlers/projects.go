@@ -0,0 +1,481 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"codewithdell/backend/internal/markdown"
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateProjectRequest represents project creation request
+type CreateProjectRequest struct {
+	Title         string             `json:"title" binding:"required,min=3"`
+	Description   string             `json:"description" binding:"required,min=10"`
+	Content       string             `json:"content"`
+	Slug          string             `json:"slug"`
+	FeaturedImage string             `json:"featured_image"`
+	Status        string             `json:"status" binding:"required,oneof=draft published archived scheduled"`
+	PublishedAt   *time.Time         `json:"published_at"`
+	LiveURL       string             `json:"live_url"`
+	SourceURL     string             `json:"source_url"`
+	DemoURL       string             `json:"demo_url"`
+	Difficulty    string             `json:"difficulty" binding:"omitempty,oneof=beginner intermediate advanced expert"`
+	Duration      string             `json:"duration"`
+	TeamSize      int                `json:"team_size" binding:"omitempty,min=1"`
+	CategoryIDs   []uint             `json:"category_ids"`
+	TagIDs        []uint             `json:"tag_ids"`
+	TechnologyIDs []uint             `json:"technology_ids"`
+	Contributors  []ContributorInput `json:"contributors" binding:"omitempty,dive"`
+}
+
+// UpdateProjectRequest represents project update request
+type UpdateProjectRequest struct {
+	Title         string             `json:"title" binding:"omitempty,min=3"`
+	Description   string             `json:"description" binding:"omitempty,min=10"`
+	Content       string             `json:"content"`
+	Slug          string             `json:"slug"`
+	FeaturedImage string             `json:"featured_image"`
+	Status        string             `json:"status" binding:"omitempty,oneof=draft published archived scheduled"`
+	PublishedAt   *time.Time         `json:"published_at"`
+	LiveURL       string             `json:"live_url"`
+	SourceURL     string             `json:"source_url"`
+	DemoURL       string             `json:"demo_url"`
+	Difficulty    string             `json:"difficulty" binding:"omitempty,oneof=beginner intermediate advanced expert"`
+	Duration      string             `json:"duration"`
+	TeamSize      int                `json:"team_size" binding:"omitempty,min=1"`
+	CategoryIDs   []uint             `json:"category_ids"`
+	TagIDs        []uint             `json:"tag_ids"`
+	TechnologyIDs []uint             `json:"technology_ids"`
+	Contributors  []ContributorInput `json:"contributors" binding:"omitempty,dive"`
+}
+
+// ProjectDetail is a project with its Markdown content rendered to HTML
+type ProjectDetail struct {
+	models.Project
+	ContentHTML string              `json:"content_html,omitempty"`
+	TOC         []markdown.TOCEntry `json:"toc,omitempty"`
+}
+
+// GetProjects handles getting published projects with pagination and filters
+func GetProjects(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	pagination := parsePagination(c)
+
+	query := db.Model(&models.Project{}).Where("projects.status = ?", models.ProjectStatusPublished)
+
+	if category := c.Query("category"); category != "" {
+		query = query.Joins("JOIN project_categories ON projects.id = project_categories.project_id").
+			Joins("JOIN categories ON project_categories.category_id = categories.id").
+			Where("categories.slug = ?", category)
+	}
+
+	if tag := c.Query("tag"); tag != "" {
+		query = query.Joins("JOIN project_tags ON projects.id = project_tags.project_id").
+			Joins("JOIN tags ON project_tags.tag_id = tags.id").
+			Where("tags.slug = ?", tag)
+	}
+
+	if difficulty := c.Query("difficulty"); difficulty != "" {
+		query = query.Where("projects.difficulty = ?", difficulty)
+	}
+
+	// Co-authored projects are listed for each of their contributors
+	if author := c.Query("author"); author != "" {
+		query = query.Where(usernameProjectsCondition, author, author)
+	}
+
+	// Filter by stack: projects must use every requested technology
+	if technologies := splitQueryList(c.QueryArray("technology")); len(technologies) > 0 {
+		query = query.Where(`projects.id IN (
+			SELECT project_technologies.project_id FROM project_technologies
+			JOIN technologies ON technologies.id = project_technologies.technology_id
+			WHERE technologies.slug IN ? AND technologies.deleted_at IS NULL
+			GROUP BY project_technologies.project_id
+			HAVING COUNT(DISTINCT technologies.id) = ?)`, technologies, len(technologies))
+	}
+
+	// Reuse the filtered query for both the count and the page
+	query = query.Session(&gorm.Session{})
+
+	// Get total count
+	var total int64
+	if err := query.Distinct("projects.id").Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	var projects []models.Project
+	if err := preloadContributors(query.Distinct("projects.*")).
+		Preload("Author").Preload("Categories").Preload("Tags").Preload("Technologies").
+		Order("projects.published_at DESC, projects.created_at DESC").
+		Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	if err := localizeProjects(c, db, projects); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project translations"})
+		return
+	}
+
+	setPaginationHeaders(c, pagination, total, true)
+	c.JSON(http.StatusOK, gin.H{
+		"projects": projects,
+		"total":    total,
+		"page":     pagination.Page,
+		"limit":    pagination.Limit,
+		"pages":    pagination.Pages(total),
+	})
+}
+
+// GetProjectBySlug handles getting a single project by slug
+func GetProjectBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	db := c.MustGet("db").(*gorm.DB)
+
+	locale, defaultLocale := requestLocale(c)
+
+	var project models.Project
+	findProject := func(column string, value interface{}) error {
+		return preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").Preload("Technologies").
+			Preload("Screenshots", orderedScreenshots).
+			Where(column+" = ? AND status = ?", value, models.ProjectStatusPublished).
+			First(&project).Error
+	}
+
+	err := findProject("slug", slug)
+	if err != nil {
+		// A translated slug serves the project in the translation's locale
+		if translation, ok := findTranslationBySlug(db, "project", slug); ok {
+			err = findProject("id", translation.EntityID)
+			locale = translation.Locale
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	// Increment view count
+	db.Model(&project).UpdateColumn("view_count", gorm.Expr("view_count + ?", 1))
+	project.IncrementViewCount()
+
+	translations, err := loadTranslations(db, "project", []uint{project.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project translations"})
+		return
+	}
+	localizeProject(&project, translations[project.ID], locale, defaultLocale)
+	c.Header("Content-Language", project.Locale)
+	setAlternateLinks(c, project.Alternates)
+
+	respondVersioned(c, project.Version, project)
+}
+
+// CreateProject handles creating a new project (admin only)
+func CreateProject(c *gin.Context) {
+	var req CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(string)
+
+	// Generate slug if not provided
+	if req.Slug == "" {
+		req.Slug = generateSlug(req.Title)
+	}
+
+	// Check if slug already exists
+	var existingProject models.Project
+	if err := db.Where("slug = ?", req.Slug).First(&existingProject).Error; err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Project with this slug already exists"})
+		return
+	}
+
+	publishedAt, err := resolvePublishedAt(req.Status, req.PublishedAt, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Convert userID to uint
+	authorID, _ := strconv.ParseUint(userID, 10, 32)
+
+	// The creator is the sole author unless contributors are given
+	contributors := req.Contributors
+	if contributors == nil {
+		contributors = []ContributorInput{{UserID: uint(authorID), Role: string(models.ContributorRoleAuthor)}}
+	}
+	primaryAuthorID, err := validateContributors(db, contributors)
+	if err != nil {
+		respondContributorError(c, err)
+		return
+	}
+
+	project := models.Project{
+		Title:         req.Title,
+		Slug:          req.Slug,
+		Description:   req.Description,
+		Content:       req.Content,
+		FeaturedImage: req.FeaturedImage,
+		Status:        models.ProjectStatus(req.Status),
+		PublishedAt:   publishedAt,
+		AuthorID:      primaryAuthorID,
+		LiveURL:       req.LiveURL,
+		SourceURL:     req.SourceURL,
+		DemoURL:       req.DemoURL,
+		Difficulty:    models.DifficultyIntermediate,
+		Duration:      req.Duration,
+		TeamSize:      1,
+	}
+	if req.Difficulty != "" {
+		project.Difficulty = models.ProjectDifficulty(req.Difficulty)
+	}
+	if req.TeamSize > 0 {
+		project.TeamSize = req.TeamSize
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&project).Error; err != nil {
+			return err
+		}
+		if err := replaceContributors(tx, "project_id", project.ID, contributors); err != nil {
+			return err
+		}
+		return replaceProjectAssociations(tx, &project, req.CategoryIDs, req.TagIDs, req.TechnologyIDs)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
+		return
+	}
+
+	invalidateRelated(c.Request.Context(), "project")
+
+	// Load relationships
+	preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").Preload("Technologies").
+		Preload("Screenshots", orderedScreenshots).
+		First(&project, project.ID)
+
+	respondSaved(c, http.StatusCreated, project.Version, project)
+}
+
+// UpdateProject handles updating a project (admin only)
+func UpdateProject(c *gin.Context) {
+	projectID := c.Param("id")
+	var req UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Find project
+	var project models.Project
+	if err := db.First(&project, projectID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	if !requireIfMatch(c, project.Version) {
+		return
+	}
+
+	// Check if new slug conflicts with existing project
+	if req.Slug != "" && req.Slug != project.Slug {
+		var existingProject models.Project
+		if err := db.Where("slug = ? AND id != ?", req.Slug, project.ID).First(&existingProject).Error; err == nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Project with this slug already exists"})
+			return
+		}
+	}
+
+	// Update fields
+	updates := make(map[string]interface{})
+	if req.Title != "" {
+		updates["title"] = req.Title
+	}
+	if req.Description != "" {
+		updates["description"] = req.Description
+	}
+	if req.Content != "" {
+		updates["content"] = req.Content
+	}
+	if req.Slug != "" {
+		updates["slug"] = req.Slug
+	}
+	if req.FeaturedImage != "" {
+		updates["featured_image"] = req.FeaturedImage
+	}
+	if req.LiveURL != "" {
+		updates["live_url"] = req.LiveURL
+	}
+	if req.SourceURL != "" {
+		updates["source_url"] = req.SourceURL
+	}
+	if req.DemoURL != "" {
+		updates["demo_url"] = req.DemoURL
+	}
+	if req.Difficulty != "" {
+		updates["difficulty"] = req.Difficulty
+	}
+	if req.Duration != "" {
+		updates["duration"] = req.Duration
+	}
+	if req.TeamSize > 0 {
+		updates["team_size"] = req.TeamSize
+	}
+	if req.Status != "" || req.PublishedAt != nil {
+		status := req.Status
+		if status == "" {
+			status = string(project.Status)
+		}
+		publishedAt, err := resolvePublishedAt(status, req.PublishedAt, project.PublishedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		updates["status"] = status
+		updates["published_at"] = publishedAt
+	}
+	if req.Contributors != nil {
+		primaryAuthorID, err := validateContributors(db, req.Contributors)
+		if err != nil {
+			respondContributorError(c, err)
+			return
+		}
+		updates["author_id"] = primaryAuthorID
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// The version is bumped even when only associations change
+		if err := updateVersioned(tx, &project, project.Version, updates); err != nil {
+			return err
+		}
+		if req.Contributors != nil {
+			if err := replaceContributors(tx, "project_id", project.ID, req.Contributors); err != nil {
+				return err
+			}
+		}
+		return replaceProjectAssociations(tx, &project, req.CategoryIDs, req.TagIDs, req.TechnologyIDs)
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "projects", project.ID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project"})
+		return
+	}
+
+	invalidateRelated(c.Request.Context(), "project")
+
+	// Load relationships
+	preloadContributors(db).Preload("Author").Preload("Categories").Preload("Tags").Preload("Technologies").
+		Preload("Screenshots", orderedScreenshots).
+		First(&project, project.ID)
+
+	respondSaved(c, http.StatusOK, project.Version, project)
+}
+
+// DeleteProject handles deleting a project (admin only)
+func DeleteProject(c *gin.Context) {
+	projectID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Find project
+	var project models.Project
+	if err := db.First(&project, projectID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	if !requireIfMatch(c, project.Version) {
+		return
+	}
+
+	// Soft delete
+	err := deleteVersioned(db, &project, project.Version)
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "projects", project.ID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
+		return
+	}
+
+	invalidateRelated(c.Request.Context(), "project")
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// splitQueryList flattens repeated and comma-separated query values
+func splitQueryList(values []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" && !seen[item] {
+				seen[item] = true
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// replaceProjectAssociations replaces the category, tag and technology
+// associations of a project. A nil slice leaves that association untouched.
+func replaceProjectAssociations(tx *gorm.DB, project *models.Project, categoryIDs, tagIDs, technologyIDs []uint) error {
+	if categoryIDs != nil {
+		var categories []models.Category
+		if len(categoryIDs) > 0 {
+			if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(project).Association("Categories").Replace(categories); err != nil {
+			return err
+		}
+	}
+
+	if tagIDs != nil {
+		var tags []models.Tag
+		if len(tagIDs) > 0 {
+			if err := tx.Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(project).Association("Tags").Replace(tags); err != nil {
+			return err
+		}
+	}
+
+	if technologyIDs != nil {
+		var technologies []models.Technology
+		if len(technologyIDs) > 0 {
+			if err := tx.Where("id IN ?", technologyIDs).Find(&technologies).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(project).Association("Technologies").Replace(technologies); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+)
+
+// errScheduleInPast is returned when a scheduled publish time is not in the future
+var errScheduleInPast = errors.New("published_at must be in the future for scheduled content")
+
+// errScheduleMissing is returned when scheduling without a publish time
+var errScheduleMissing = errors.New("published_at is required for scheduled content")
+
+// resolvePublishedAt returns the publish time for content moving to status.
+// Published content keeps its first publish date or gets the current time,
+// scheduled content needs a future time, and other states keep what they have.
+func resolvePublishedAt(status string, requested, current *time.Time) (*time.Time, error) {
+	switch status {
+	case "published":
+		if requested != nil {
+			return requested, nil
+		}
+		if current != nil {
+			return current, nil
+		}
+		now := time.Now()
+		return &now, nil
+	case "scheduled":
+		publishAt := requested
+		if publishAt == nil {
+			publishAt = current
+		}
+		if publishAt == nil {
+			return nil, errScheduleMissing
+		}
+		if !publishAt.After(time.Now()) {
+			return nil, errScheduleInPast
+		}
+		return publishAt, nil
+	default:
+		if requested != nil {
+			return requested, nil
+		}
+		return current, nil
+	}
+}
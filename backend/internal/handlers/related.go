@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/internal/redis"
+	"codewithdell/backend/internal/related"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRelatedLimit = 4
+	maxRelatedLimit     = 12
+
+	// relatedCandidatePool caps how many candidates are scored per request
+	relatedCandidatePool = 100
+	relatedCacheTTL      = time.Hour
+)
+
+// relatedSource describes the tables behind posts or projects for recommendations
+type relatedSource struct {
+	kind          string
+	table         string
+	summaryColumn string
+	tagTable      string
+	categoryTable string
+	column        string
+	status        string
+}
+
+var (
+	relatedPostSource = relatedSource{
+		kind:          "post",
+		table:         "posts",
+		summaryColumn: "excerpt",
+		tagTable:      "post_tags",
+		categoryTable: "post_categories",
+		column:        "post_id",
+		status:        string(models.PostStatusPublished),
+	}
+	relatedProjectSource = relatedSource{
+		kind:          "project",
+		table:         "projects",
+		summaryColumn: "description",
+		tagTable:      "project_tags",
+		categoryTable: "project_categories",
+		column:        "project_id",
+		status:        string(models.ProjectStatusPublished),
+	}
+)
+
+// relatedCandidate is a scored row of the candidate query
+type relatedCandidate struct {
+	ID               uint
+	Title            string
+	Summary          string
+	SharedTags       int
+	SharedCategories int
+	PublishedAt      *time.Time
+	CreatedAt        time.Time
+}
+
+// GetRelatedPosts handles recommending published posts related to a post
+func GetRelatedPosts(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var post models.Post
+	if err := db.Where("slug = ? AND status = ?", c.Param("slug"), models.PostStatusPublished).
+		First(&post).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	target := related.Item{ID: post.ID, Title: post.Title, Excerpt: post.Excerpt}
+	ids, err := relatedIDs(c.Request.Context(), db, relatedPostSource, target, relatedLimit(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related posts"})
+		return
+	}
+
+	posts := []models.Post{}
+	if len(ids) > 0 {
+		if err := db.Preload("Author").Preload("Tags").
+			Where("id IN ? AND status = ?", ids, models.PostStatusPublished).
+			Find(&posts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related posts"})
+			return
+		}
+		sortByIDs(posts, ids, func(post models.Post) uint { return post.ID })
+		if err := localizePosts(c, db, posts); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related posts"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts": posts,
+		"total": len(posts),
+	})
+}
+
+// GetRelatedProjects handles recommending published projects related to a project
+func GetRelatedProjects(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var project models.Project
+	if err := db.Where("slug = ? AND status = ?", c.Param("slug"), models.ProjectStatusPublished).
+		First(&project).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	target := related.Item{ID: project.ID, Title: project.Title, Excerpt: project.Description}
+	ids, err := relatedIDs(c.Request.Context(), db, relatedProjectSource, target, relatedLimit(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related projects"})
+		return
+	}
+
+	projects := []models.Project{}
+	if len(ids) > 0 {
+		if err := db.Preload("Author").Preload("Tags").Preload("Technologies").
+			Where("id IN ? AND status = ?", ids, models.ProjectStatusPublished).
+			Find(&projects).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related projects"})
+			return
+		}
+		sortByIDs(projects, ids, func(project models.Project) uint { return project.ID })
+		if err := localizeProjects(c, db, projects); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related projects"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projects": projects,
+		"total":    len(projects),
+	})
+}
+
+// relatedLimit reads the limit query parameter
+func relatedLimit(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit < 1 {
+		return defaultRelatedLimit
+	}
+	if limit > maxRelatedLimit {
+		return maxRelatedLimit
+	}
+	return limit
+}
+
+// relatedIDs returns the ranked ids of content related to target, served from
+// Redis when a ranking for the current generation is cached
+func relatedIDs(ctx context.Context, db *gorm.DB, source relatedSource, target related.Item, limit int) ([]uint, error) {
+	// The full ranking is cached so any limit can be served from it
+	key := ""
+	if redis.GetClient() != nil {
+		generation, err := redis.Get(ctx, relatedGenerationKey(source.kind))
+		if err != nil {
+			generation = "0"
+		}
+		key = fmt.Sprintf("related:%s:%s:%d", source.kind, generation, target.ID)
+
+		if cached, err := redis.Get(ctx, key); err == nil {
+			var ids []uint
+			if json.Unmarshal([]byte(cached), &ids) == nil {
+				return truncateIDs(ids, limit), nil
+			}
+		}
+	}
+
+	candidates, err := relatedCandidates(db, source, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	ids := related.Rank(target, candidates, time.Now(), maxRelatedLimit)
+
+	if key != "" {
+		if data, err := json.Marshal(ids); err == nil {
+			if err := redis.Set(ctx, key, data, relatedCacheTTL); err != nil {
+				log.Warn().Err(err).Str("key", key).Msg("Failed to cache related content")
+			}
+		}
+	}
+
+	return truncateIDs(ids, limit), nil
+}
+
+// relatedCandidates loads published content with the number of tags and
+// categories it shares with the target. Content sharing the most comes first,
+// then the most recent, so unrelated content still fills short lists.
+func relatedCandidates(db *gorm.DB, source relatedSource, targetID uint) ([]related.Item, error) {
+	shared := func(joinTable, column string) *gorm.DB {
+		return db.Table(joinTable+" AS candidate").
+			Select("COUNT(*)").
+			Joins("JOIN "+joinTable+" AS target ON target."+column+" = candidate."+column+" AND target."+source.column+" = ?", targetID).
+			Where("candidate." + source.column + " = " + source.table + ".id")
+	}
+
+	candidates := db.Table(source.table).
+		Select(source.table+".id, "+source.table+".title, "+source.table+"."+source.summaryColumn+" AS summary, "+
+			source.table+".published_at, "+source.table+".created_at, (?) AS shared_tags, (?) AS shared_categories",
+			shared(source.tagTable, "tag_id"), shared(source.categoryTable, "category_id")).
+		Where(source.table+".id <> ? AND "+source.table+".status = ? AND "+source.table+".deleted_at IS NULL", targetID, source.status)
+
+	var rows []relatedCandidate
+	err := db.Table("(?) AS candidates", candidates).
+		Order("shared_tags + shared_categories DESC, COALESCE(published_at, created_at) DESC").
+		Limit(relatedCandidatePool).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]related.Item, len(rows))
+	for i, row := range rows {
+		date := row.CreatedAt
+		if row.PublishedAt != nil {
+			date = *row.PublishedAt
+		}
+		items[i] = related.Item{
+			ID:               row.ID,
+			Title:            row.Title,
+			Excerpt:          row.Summary,
+			SharedTags:       row.SharedTags,
+			SharedCategories: row.SharedCategories,
+			Date:             date,
+		}
+	}
+	return items, nil
+}
+
+// invalidateRelated drops every cached ranking of posts or projects ("post" or
+// "project"). Rankings of other content can include the changed one, so the
+// cache generation is bumped rather than deleting a single key.
+func invalidateRelated(ctx context.Context, kind string) {
+	if redis.GetClient() == nil {
+		return
+	}
+	if _, err := redis.Incr(ctx, relatedGenerationKey(kind)); err != nil {
+		log.Warn().Err(err).Str("kind", kind).Msg("Failed to invalidate related content")
+	}
+}
+
+func relatedGenerationKey(kind string) string {
+	return "related:" + kind + ":generation"
+}
+
+func truncateIDs(ids []uint, limit int) []uint {
+	if len(ids) > limit {
+		return ids[:limit]
+	}
+	return ids
+}
+
+// sortByIDs orders items to match ids
+func sortByIDs[T any](items []T, ids []uint, id func(T) uint) {
+	position := make(map[uint]int, len(ids))
+	for i, itemID := range ids {
+		position[itemID] = i
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return position[id(items[i])] < position[id(items[j])]
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"codewithdell/backend/internal/markdown"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/internal/redis"
+
+	"github.com/rs/zerolog/log"
+)
+
+const renderedContentTTL = 24 * time.Hour
+
+var (
+	contentCachesOnce       sync.Once
+	postContentCache        *markdown.Cache
+	projectContentCache     *markdown.Cache
+	translationContentCache *markdown.Cache
+)
+
+// initContentCaches creates the caches of rendered content on first use so
+// they pick up the Redis client set up by the server
+func initContentCaches() {
+	contentCachesOnce.Do(func() {
+		renderer := markdown.NewRenderer()
+		postContentCache = markdown.NewCache(renderer, redis.GetClient(), "markdown:post", renderedContentTTL)
+		projectContentCache = markdown.NewCache(renderer, redis.GetClient(), "markdown:project", renderedContentTTL)
+		translationContentCache = markdown.NewCache(renderer, redis.GetClient(), "markdown:translation", renderedContentTTL)
+	})
+}
+
+// postContent returns the cache of rendered post content
+func postContent() *markdown.Cache {
+	initContentCaches()
+	return postContentCache
+}
+
+// projectContent returns the cache of rendered project content
+func projectContent() *markdown.Cache {
+	initContentCaches()
+	return projectContentCache
+}
+
+// translationContent returns the cache of rendered translated content
+func translationContent() *markdown.Cache {
+	initContentCaches()
+	return translationContentCache
+}
+
+// renderPostContent renders a post's Markdown content through the cache
+func renderPostContent(ctx context.Context, post *models.Post) (*markdown.Document, error) {
+	return postContent().Render(ctx, post.ID, post.Content)
+}
+
+// renderProjectContent renders a project's Markdown content through the cache
+func renderProjectContent(ctx context.Context, project *models.Project) (*markdown.Document, error) {
+	return projectContent().Render(ctx, project.ID, project.Content)
+}
+
+// renderTranslationContent renders a translation's Markdown content through the cache
+func renderTranslationContent(ctx context.Context, translation *models.Translation) (*markdown.Document, error) {
+	return translationContent().Render(ctx, translation.ID, translation.Content)
+}
+
+// invalidatePostContent drops the rendered content of a post after its source changed
+func invalidatePostContent(ctx context.Context, postID uint) {
+	if err := postContent().Invalidate(ctx, postID); err != nil {
+		log.Warn().Err(err).Uint("post_id", postID).Msg("Failed to invalidate rendered post content")
+	}
+}
+
+// contentMetricUpdates returns the reading metric columns for new post content.
+// Without an explicit excerpt, one generated from the previous content is
+// regenerated while a hand written one is kept.
+func contentMetricUpdates(post *models.Post, content, excerpt string) map[string]interface{} {
+	summary := markdown.Analyze(content)
+	updates := map[string]interface{}{
+		"word_count":   summary.WordCount,
+		"reading_time": summary.ReadingTime,
+	}
+	if excerpt == "" && (post.Excerpt == "" || post.Excerpt == markdown.Analyze(post.Content).Excerpt) {
+		updates["excerpt"] = summary.Excerpt
+	}
+	return updates
+}
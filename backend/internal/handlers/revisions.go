@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"codewithdell/backend/internal/markdown"
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pmezard/go-difflib/difflib"
+	"gorm.io/gorm"
+)
+
+// GetPostRevisions handles listing the revisions of a post, newest first (admin only)
+func GetPostRevisions(c *gin.Context) {
+	postID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var post models.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	// Content is left out of the listing; fetch a single revision to read it
+	var revisions []models.PostRevision
+	if err := db.Preload("Editor").
+		Select("id", "uuid", "post_id", "version", "title", "excerpt", "editor_id", "restored_from", "created_at").
+		Where("post_id = ?", post.ID).
+		Order("version DESC").
+		Find(&revisions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch revisions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post_id":   post.ID,
+		"revisions": revisions,
+		"total":     len(revisions),
+	})
+}
+
+// GetPostRevision handles getting a single revision of a post (admin only)
+func GetPostRevision(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	revision, ok := findPostRevision(c, db, c.Param("version"))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffPostRevisions handles showing a unified diff between two revisions of a post (admin only)
+func DiffPostRevisions(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	if c.Query("from") == "" || c.Query("to") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to revision versions are required"})
+		return
+	}
+
+	from, ok := findPostRevision(c, db, c.Query("from"))
+	if !ok {
+		return
+	}
+	to, ok := findPostRevision(c, db, c.Query("to"))
+	if !ok {
+		return
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from.Snapshot()),
+		B:        difflib.SplitLines(to.Snapshot()),
+		FromFile: fmt.Sprintf("revision %d", from.Version),
+		ToFile:   fmt.Sprintf("revision %d", to.Version),
+		FromDate: from.CreatedAt.Format("2006-01-02 15:04:05"),
+		ToDate:   to.CreatedAt.Format("2006-01-02 15:04:05"),
+		Context:  3,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to diff revisions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post_id": from.PostID,
+		"from":    from.Version,
+		"to":      to.Version,
+		"diff":    diff,
+	})
+}
+
+// RestorePostRevision handles restoring an old revision as a new revision (admin only)
+func RestorePostRevision(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(string)
+	editorID, _ := strconv.ParseUint(userID, 10, 32)
+
+	revision, ok := findPostRevision(c, db, c.Param("version"))
+	if !ok {
+		return
+	}
+
+	var post models.Post
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&post, revision.PostID).Error; err != nil {
+			return err
+		}
+
+		summary := markdown.Analyze(revision.Content)
+		if err := tx.Model(&post).Updates(map[string]interface{}{
+			"title":        revision.Title,
+			"excerpt":      revision.Excerpt,
+			"content":      revision.Content,
+			"word_count":   summary.WordCount,
+			"reading_time": summary.ReadingTime,
+			"version":      gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+
+		restored, err := recordPostRevision(tx, &post, uint(editorID))
+		if err != nil {
+			return err
+		}
+		return tx.Model(restored).Update("restored_from", revision.Version).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore revision"})
+		return
+	}
+
+	invalidatePostContent(c.Request.Context(), post.ID)
+	invalidateRelated(c.Request.Context(), "post")
+
+	// Load relationships
+	preloadContributors(db).Preload("Author").Preload("Tags").First(&post, post.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Revision restored successfully",
+		"restored_from": revision.Version,
+		"post":          post,
+	})
+}
+
+// findPostRevision loads a revision of the post in the :id route parameter.
+// It writes the error response and returns false when it cannot be found.
+func findPostRevision(c *gin.Context, db *gorm.DB, version string) (*models.PostRevision, bool) {
+	versionNumber, err := strconv.Atoi(version)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision version"})
+		return nil, false
+	}
+
+	var revision models.PostRevision
+	if err := db.Preload("Editor").
+		Where("post_id = ? AND version = ?", c.Param("id"), versionNumber).
+		First(&revision).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return nil, false
+	}
+
+	return &revision, true
+}
+
+// recordPostRevision stores the current title, excerpt and content of a post
+// as its next revision
+func recordPostRevision(tx *gorm.DB, post *models.Post, editorID uint) (*models.PostRevision, error) {
+	var latest int
+	if err := tx.Model(&models.PostRevision{}).
+		Where("post_id = ?", post.ID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return nil, err
+	}
+
+	revision := models.PostRevision{
+		PostID:   post.ID,
+		Version:  latest + 1,
+		Title:    post.Title,
+		Excerpt:  post.Excerpt,
+		Content:  post.Content,
+		EditorID: editorID,
+	}
+	if err := tx.Omit("Editor").Create(&revision).Error; err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// ensureBaselineRevision records the current state of a post written before
+// revisions were tracked, so the first tracked update can still be diffed and undone
+func ensureBaselineRevision(tx *gorm.DB, post *models.Post) error {
+	var count int64
+	if err := tx.Model(&models.PostRevision{}).Where("post_id = ?", post.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	revision := models.PostRevision{
+		PostID:    post.ID,
+		Version:   1,
+		Title:     post.Title,
+		Excerpt:   post.Excerpt,
+		Content:   post.Content,
+		EditorID:  post.AuthorID,
+		CreatedAt: post.UpdatedAt,
+	}
+	return tx.Omit("Editor").Create(&revision).Error
+}
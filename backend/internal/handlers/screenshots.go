@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ScreenshotInput represents a single screenshot to attach to a project
+type ScreenshotInput struct {
+	ImageURL string `json:"image_url" binding:"required"`
+	Title    string `json:"title" binding:"max=200"`
+	AltText  string `json:"alt_text" binding:"max=300"`
+}
+
+// AddScreenshotsRequest represents a request to attach screenshots to a project
+type AddScreenshotsRequest struct {
+	Screenshots []ScreenshotInput `json:"screenshots" binding:"required,min=1,dive"`
+}
+
+// ReorderScreenshotsRequest represents a screenshot reorder request
+type ReorderScreenshotsRequest struct {
+	ScreenshotIDs []uint `json:"screenshot_ids" binding:"required,min=1"`
+}
+
+// UpdateScreenshotRequest represents a screenshot caption update request
+type UpdateScreenshotRequest struct {
+	Title   *string `json:"title" binding:"omitempty,max=200"`
+	AltText *string `json:"alt_text" binding:"omitempty,max=300"`
+}
+
+// orderedScreenshots preloads screenshots sorted by their gallery position
+func orderedScreenshots(db *gorm.DB) *gorm.DB {
+	return db.Order(`screenshots."order" ASC, screenshots.id ASC`)
+}
+
+// AddProjectScreenshots handles attaching uploaded images to a project (admin only)
+func AddProjectScreenshots(c *gin.Context) {
+	projectID := c.Param("id")
+	var req AddScreenshotsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var project models.Project
+	if err := db.First(&project, projectID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	// Only images that went through the upload endpoint can be attached
+	for _, input := range req.Screenshots {
+		if !isUploadedImage(input.ImageURL) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":     "Screenshot image must be an uploaded image",
+				"image_url": input.ImageURL,
+			})
+			return
+		}
+	}
+
+	var screenshots []models.Screenshot
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// New screenshots are appended to the end of the gallery
+		var maxOrder *int
+		if err := tx.Model(&models.Screenshot{}).
+			Where("project_id = ?", project.ID).
+			Select(`MAX("order")`).
+			Scan(&maxOrder).Error; err != nil {
+			return err
+		}
+		next := 0
+		if maxOrder != nil {
+			next = *maxOrder + 1
+		}
+
+		for i, input := range req.Screenshots {
+			screenshots = append(screenshots, models.Screenshot{
+				ProjectID: project.ID,
+				Title:     input.Title,
+				ImageURL:  input.ImageURL,
+				AltText:   input.AltText,
+				Order:     next + i,
+			})
+		}
+		return tx.Omit("Project").Create(&screenshots).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add screenshots"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "Screenshots added successfully",
+		"screenshots": screenshots,
+	})
+}
+
+// ReorderProjectScreenshots handles reordering a project's screenshots in one transaction (admin only)
+func ReorderProjectScreenshots(c *gin.Context) {
+	projectID := c.Param("id")
+	var req ReorderScreenshotsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var project models.Project
+	if err := db.First(&project, projectID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	var existingIDs []uint
+	if err := db.Model(&models.Screenshot{}).Where("project_id = ?", project.ID).Pluck("id", &existingIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch screenshots"})
+		return
+	}
+
+	// The new order must list every screenshot of the project exactly once
+	if !sameIDSet(existingIDs, req.ScreenshotIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "screenshot_ids must contain each screenshot of the project exactly once"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range req.ScreenshotIDs {
+			if err := tx.Model(&models.Screenshot{}).
+				Where("id = ? AND project_id = ?", id, project.ID).
+				UpdateColumn("order", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder screenshots"})
+		return
+	}
+
+	var screenshots []models.Screenshot
+	orderedScreenshots(db).Where("project_id = ?", project.ID).Find(&screenshots)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Screenshots reordered successfully",
+		"screenshots": screenshots,
+	})
+}
+
+// UpdateScreenshot handles editing a screenshot's caption and alt text (admin only)
+func UpdateScreenshot(c *gin.Context) {
+	screenshotID := c.Param("id")
+	var req UpdateScreenshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var screenshot models.Screenshot
+	if err := db.First(&screenshot, screenshotID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Screenshot not found"})
+		return
+	}
+
+	// Pointers let callers clear a caption by sending an empty string
+	updates := make(map[string]interface{})
+	if req.Title != nil {
+		updates["title"] = *req.Title
+	}
+	if req.AltText != nil {
+		updates["alt_text"] = *req.AltText
+	}
+
+	if len(updates) > 0 {
+		if err := db.Model(&screenshot).Updates(updates).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update screenshot"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Screenshot updated successfully",
+		"screenshot": screenshot,
+	})
+}
+
+// DeleteScreenshot handles removing a screenshot from a project (admin only)
+func DeleteScreenshot(c *gin.Context) {
+	screenshotID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var screenshot models.Screenshot
+	if err := db.First(&screenshot, screenshotID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Screenshot not found"})
+		return
+	}
+
+	if err := db.Delete(&screenshot).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete screenshot"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Screenshot deleted successfully"})
+}
+
+// isUploadedImage checks that an image URL points to an existing file under uploads/images
+func isUploadedImage(imageURL string) bool {
+	const prefix = "/uploads/images/"
+	if !strings.HasPrefix(imageURL, prefix) {
+		return false
+	}
+
+	filename := strings.TrimPrefix(imageURL, prefix)
+	if filename == "" || strings.Contains(filename, "..") || strings.Contains(filename, "/") {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join("uploads/images", filename))
+	return err == nil && !info.IsDir()
+}
+
+// sameIDSet reports whether ids is a permutation of existing
+func sameIDSet(existing, ids []uint) bool {
+	if len(existing) != len(ids) {
+		return false
+	}
+
+	seen := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		seen[id] = true
+	}
+	for _, id := range ids {
+		if !seen[id] {
+			return false
+		}
+		delete(seen, id)
+	}
+
+	return true
+}
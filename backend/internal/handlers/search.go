@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 	"strings"
 
 	"codewithdell/backend/internal/models"
@@ -57,19 +56,35 @@ func Search(c *gin.Context) {
 	if req.SortOrder == "" {
 		req.SortOrder = "desc"
 	}
+	// Only admins may search unpublished content
+	if c.GetString("role") != string(models.RoleAdmin) {
+		req.Status = ""
+	}
 
 	db := c.MustGet("db").(*gorm.DB)
 
+	// Only content in the requested locale matches
+	locale, defaultLocale := requestLocale(c)
+	if locale == defaultLocale {
+		locale = ""
+	}
+
 	switch req.Type {
 	case "posts":
-		results, total, err := searchPosts(db, req)
+		results, total, err := searchPosts(db, req, locale)
+		if err == nil {
+			err = localizePosts(c, db, results)
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search posts"})
 			return
 		}
 		c.JSON(http.StatusOK, createSearchResponse(req, results, total))
 	case "projects":
-		results, total, err := searchProjects(db, req)
+		results, total, err := searchProjects(db, req, locale)
+		if err == nil {
+			err = localizeProjects(c, db, results)
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search projects"})
 			return
@@ -77,13 +92,19 @@ func Search(c *gin.Context) {
 		c.JSON(http.StatusOK, createSearchResponse(req, results, total))
 	case "all", "":
 		// Search both posts and projects
-		postResults, postTotal, err := searchPosts(db, req)
+		postResults, postTotal, err := searchPosts(db, req, locale)
+		if err == nil {
+			err = localizePosts(c, db, postResults)
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search posts"})
 			return
 		}
 
-		projectResults, projectTotal, err := searchProjects(db, req)
+		projectResults, projectTotal, err := searchProjects(db, req, locale)
+		if err == nil {
+			err = localizeProjects(c, db, projectResults)
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search projects"})
 			return
@@ -100,15 +121,21 @@ func Search(c *gin.Context) {
 	}
 }
 
-// searchPosts performs search on posts
-func searchPosts(db *gorm.DB, req SearchRequest) ([]models.Post, int64, error) {
+// searchPosts performs search on posts. With a locale, the query is matched
+// against the posts' translations in that locale instead of the posts.
+func searchPosts(db *gorm.DB, req SearchRequest, locale string) ([]models.Post, int64, error) {
 	query := db.Preload("Author").Preload("Tags").Preload("Categories")
 
 	// Apply search query
 	if req.Query != "" {
 		searchQuery := "%" + strings.ToLower(req.Query) + "%"
-		query = query.Where("LOWER(title) LIKE ? OR LOWER(content) LIKE ? OR LOWER(excerpt) LIKE ?",
-			searchQuery, searchQuery, searchQuery)
+		if locale != "" {
+			query = query.Where("posts.id IN (?)", translationMatches(db, "post", locale,
+				"LOWER(title) LIKE ? OR LOWER(content) LIKE ? OR LOWER(excerpt) LIKE ?", searchQuery, searchQuery, searchQuery))
+		} else {
+			query = query.Where("LOWER(title) LIKE ? OR LOWER(content) LIKE ? OR LOWER(excerpt) LIKE ?",
+				searchQuery, searchQuery, searchQuery)
+		}
 	}
 
 	// Apply filters
@@ -124,9 +151,9 @@ func searchPosts(db *gorm.DB, req SearchRequest) ([]models.Post, int64, error) {
 			Where("tags.slug IN ?", req.Tags)
 	}
 
+	// Co-authored posts are found for each of their contributors
 	if req.Author != "" {
-		query = query.Joins("JOIN users ON posts.author_id = users.id").
-			Where("users.username = ?", req.Author)
+		query = query.Where(usernamePostsCondition, req.Author, req.Author)
 	}
 
 	if req.Status != "" {
@@ -148,9 +175,12 @@ func searchPosts(db *gorm.DB, req SearchRequest) ([]models.Post, int64, error) {
 		fallthrough
 	default:
 		// For relevance, we'll sort by a combination of factors
-		if req.Query != "" {
+		if req.Query != "" && locale != "" {
+			query = query.Order(gorm.Expr("CASE WHEN posts.id IN (?) THEN 1 ELSE 2 END",
+				translationMatches(db, "post", locale, "LOWER(title) LIKE ?", "%"+strings.ToLower(req.Query)+"%")))
+		} else if req.Query != "" {
 			// If there's a search query, prioritize posts that match the query
-			query = query.Order("CASE WHEN LOWER(title) LIKE ? THEN 1 ELSE 2 END", "%"+strings.ToLower(req.Query)+"%")
+			query = query.Order(gorm.Expr("CASE WHEN LOWER(title) LIKE ? THEN 1 ELSE 2 END", "%"+strings.ToLower(req.Query)+"%"))
 		}
 		query = query.Order("posts.view_count DESC, posts.created_at DESC")
 	}
@@ -168,15 +198,21 @@ func searchPosts(db *gorm.DB, req SearchRequest) ([]models.Post, int64, error) {
 	return posts, total, err
 }
 
-// searchProjects performs search on projects
-func searchProjects(db *gorm.DB, req SearchRequest) ([]models.Project, int64, error) {
+// searchProjects performs search on projects. With a locale, the query is
+// matched against the projects' translations in that locale instead.
+func searchProjects(db *gorm.DB, req SearchRequest, locale string) ([]models.Project, int64, error) {
 	query := db.Preload("Technologies").Preload("Tags").Preload("Categories")
 
 	// Apply search query
 	if req.Query != "" {
 		searchQuery := "%" + strings.ToLower(req.Query) + "%"
-		query = query.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ? OR LOWER(content) LIKE ?",
-			searchQuery, searchQuery, searchQuery)
+		if locale != "" {
+			query = query.Where("projects.id IN (?)", translationMatches(db, "project", locale,
+				"LOWER(title) LIKE ? OR LOWER(excerpt) LIKE ? OR LOWER(content) LIKE ?", searchQuery, searchQuery, searchQuery))
+		} else {
+			query = query.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ? OR LOWER(content) LIKE ?",
+				searchQuery, searchQuery, searchQuery)
+		}
 	}
 
 	// Apply filters
@@ -211,9 +247,12 @@ func searchProjects(db *gorm.DB, req SearchRequest) ([]models.Project, int64, er
 		fallthrough
 	default:
 		// For relevance, we'll sort by a combination of factors
-		if req.Query != "" {
+		if req.Query != "" && locale != "" {
+			query = query.Order(gorm.Expr("CASE WHEN projects.id IN (?) THEN 1 ELSE 2 END",
+				translationMatches(db, "project", locale, "LOWER(title) LIKE ?", "%"+strings.ToLower(req.Query)+"%")))
+		} else if req.Query != "" {
 			// If there's a search query, prioritize projects that match the query
-			query = query.Order("CASE WHEN LOWER(title) LIKE ? THEN 1 ELSE 2 END", "%"+strings.ToLower(req.Query)+"%")
+			query = query.Order(gorm.Expr("CASE WHEN LOWER(title) LIKE ? THEN 1 ELSE 2 END", "%"+strings.ToLower(req.Query)+"%"))
 		}
 		query = query.Order("projects.view_count DESC, projects.created_at DESC")
 	}
@@ -231,6 +270,13 @@ func searchProjects(db *gorm.DB, req SearchRequest) ([]models.Project, int64, er
 	return projects, total, err
 }
 
+// translationMatches selects the ids of content whose translation in locale matches condition
+func translationMatches(db *gorm.DB, entityType, locale, condition string, args ...interface{}) *gorm.DB {
+	return db.Model(&models.Translation{}).Select("entity_id").
+		Where("entity_type = ? AND locale = ?", entityType, locale).
+		Where("("+condition+")", args...)
+}
+
 // createSearchResponse creates a standardized search response
 func createSearchResponse(req SearchRequest, results interface{}, total int64) SearchResponse {
 	pages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
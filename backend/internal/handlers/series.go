@@ -0,0 +1,483 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateSeriesRequest represents series creation request
+type CreateSeriesRequest struct {
+	Title         string `json:"title" binding:"required,min=3,max=200"`
+	Slug          string `json:"slug"`
+	Description   string `json:"description"`
+	FeaturedImage string `json:"featured_image"`
+}
+
+// UpdateSeriesRequest represents series update request
+type UpdateSeriesRequest struct {
+	Title         string `json:"title" binding:"omitempty,min=3,max=200"`
+	Slug          string `json:"slug"`
+	Description   string `json:"description"`
+	FeaturedImage string `json:"featured_image"`
+}
+
+// SeriesPostsRequest represents a request to add or reorder posts in a series
+type SeriesPostsRequest struct {
+	PostIDs []uint `json:"post_ids" binding:"required,min=1"`
+}
+
+// SeriesPostLink is a neighbouring part of a series
+type SeriesPostLink struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	Position int    `json:"position"`
+}
+
+// SeriesNavigation describes where a post sits in its series
+type SeriesNavigation struct {
+	ID       uint            `json:"id"`
+	Title    string          `json:"title"`
+	Slug     string          `json:"slug"`
+	Position int             `json:"position"`
+	Total    int             `json:"total"`
+	Previous *SeriesPostLink `json:"previous"`
+	Next     *SeriesPostLink `json:"next"`
+}
+
+var errPostInOtherSeries = errors.New("post already belongs to another series")
+
+// orderedSeriesPosts preloads series posts sorted by their position
+func orderedSeriesPosts(db *gorm.DB) *gorm.DB {
+	return db.Order("posts.series_position ASC, posts.id ASC")
+}
+
+// publishedSeriesPosts preloads the published posts of a series in order
+func publishedSeriesPosts(db *gorm.DB) *gorm.DB {
+	return orderedSeriesPosts(db).Where("posts.status = ?", models.PostStatusPublished)
+}
+
+// GetSeriesList handles getting all series with their published post counts
+func GetSeriesList(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	pagination := parsePagination(c)
+
+	var total int64
+	if err := db.Model(&models.Series{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch series"})
+		return
+	}
+
+	var series []models.Series
+	if err := db.Model(&models.Series{}).
+		Select(`series.*, (SELECT COUNT(*) FROM posts
+			WHERE posts.series_id = series.id AND posts.status = ? AND posts.deleted_at IS NULL) AS post_count`,
+			models.PostStatusPublished).
+		Order("series.created_at DESC").
+		Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&series).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch series"})
+		return
+	}
+
+	setPaginationHeaders(c, pagination, total, true)
+	c.JSON(http.StatusOK, gin.H{
+		"series": series,
+		"total":  total,
+		"page":   pagination.Page,
+		"limit":  pagination.Limit,
+		"pages":  pagination.Pages(total),
+	})
+}
+
+// GetSeriesBySlug handles getting a series and its published posts in order
+func GetSeriesBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var series models.Series
+	if err := db.Preload("Posts", publishedSeriesPosts).
+		Preload("Posts.Author").
+		Where("slug = ?", slug).
+		First(&series).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+	series.PostCount = int64(len(series.Posts))
+
+	c.JSON(http.StatusOK, series)
+}
+
+// CreateSeries handles creating a new series (admin only)
+func CreateSeries(c *gin.Context) {
+	var req CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Generate slug if not provided
+	if req.Slug == "" {
+		req.Slug = generateSlug(req.Title)
+	}
+
+	// Check if slug already exists
+	var existingSeries models.Series
+	if err := db.Where("slug = ?", req.Slug).First(&existingSeries).Error; err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Series with this slug already exists"})
+		return
+	}
+
+	series := models.Series{
+		Title:         req.Title,
+		Slug:          req.Slug,
+		Description:   req.Description,
+		FeaturedImage: req.FeaturedImage,
+	}
+
+	if err := db.Create(&series).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create series"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Series created successfully",
+		"series":  series,
+	})
+}
+
+// UpdateSeries handles updating a series (admin only)
+func UpdateSeries(c *gin.Context) {
+	var req UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	seriesID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var series models.Series
+	if err := db.First(&series, seriesID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	// Check if new slug conflicts with existing series
+	if req.Slug != "" && req.Slug != series.Slug {
+		var existingSeries models.Series
+		if err := db.Where("slug = ? AND id != ?", req.Slug, series.ID).First(&existingSeries).Error; err == nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Series with this slug already exists"})
+			return
+		}
+		series.Slug = req.Slug
+	}
+
+	if req.Title != "" {
+		series.Title = req.Title
+	}
+	if req.Description != "" {
+		series.Description = req.Description
+	}
+	if req.FeaturedImage != "" {
+		series.FeaturedImage = req.FeaturedImage
+	}
+
+	if err := db.Save(&series).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Series updated successfully",
+		"series":  series,
+	})
+}
+
+// DeleteSeries handles deleting a series; its posts are kept and detached (admin only)
+func DeleteSeries(c *gin.Context) {
+	seriesID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var series models.Series
+	if err := db.First(&series, seriesID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Post{}).Where("series_id = ?", series.ID).
+			UpdateColumns(map[string]interface{}{"series_id": nil, "series_position": 0}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&series).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Series deleted successfully"})
+}
+
+// AddSeriesPosts handles appending posts to the end of a series (admin only)
+func AddSeriesPosts(c *gin.Context) {
+	seriesID := c.Param("id")
+	var req SeriesPostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var series models.Series
+	if err := db.First(&series, seriesID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	var posts []models.Post
+	if err := db.Where("id IN ?", req.PostIDs).Find(&posts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch posts"})
+		return
+	}
+	if len(posts) != len(uniqueIDs(req.PostIDs)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	// A post belongs to at most one series; posts already in this one keep their place
+	alreadyAdded := make(map[uint]bool)
+	for _, post := range posts {
+		if post.SeriesID == nil {
+			continue
+		}
+		if *post.SeriesID != series.ID {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     errPostInOtherSeries.Error(),
+				"post_id":   post.ID,
+				"series_id": *post.SeriesID,
+			})
+			return
+		}
+		alreadyAdded[post.ID] = true
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var maxPosition int
+		if err := tx.Model(&models.Post{}).
+			Where("series_id = ?", series.ID).
+			Select("COALESCE(MAX(series_position), 0)").
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+
+		position := maxPosition
+		for _, id := range uniqueIDs(req.PostIDs) {
+			if alreadyAdded[id] {
+				continue
+			}
+
+			// The series_id condition keeps concurrent requests from
+			// moving a post that another series just claimed
+			position++
+			result := tx.Model(&models.Post{}).
+				Where("id = ? AND series_id IS NULL", id).
+				UpdateColumns(map[string]interface{}{"series_id": series.ID, "series_position": position})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errPostInOtherSeries
+			}
+		}
+		return renumberSeriesPosts(tx, series.ID)
+	})
+	if errors.Is(err, errPostInOtherSeries) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add posts to series"})
+		return
+	}
+
+	respondWithSeriesPosts(c, db, series, "Posts added to series successfully")
+}
+
+// RemoveSeriesPost handles removing a post from a series (admin only)
+func RemoveSeriesPost(c *gin.Context) {
+	seriesID := c.Param("id")
+	postID := c.Param("post_id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var series models.Series
+	if err := db.First(&series, seriesID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	var post models.Post
+	if err := db.Where("id = ? AND series_id = ?", postID, series.ID).First(&post).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post is not part of this series"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&post).
+			UpdateColumns(map[string]interface{}{"series_id": nil, "series_position": 0}).Error; err != nil {
+			return err
+		}
+		return renumberSeriesPosts(tx, series.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove post from series"})
+		return
+	}
+
+	respondWithSeriesPosts(c, db, series, "Post removed from series successfully")
+}
+
+// ReorderSeriesPosts handles reordering the posts of a series in one transaction (admin only)
+func ReorderSeriesPosts(c *gin.Context) {
+	seriesID := c.Param("id")
+	var req SeriesPostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	var series models.Series
+	if err := db.First(&series, seriesID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	var existingIDs []uint
+	if err := db.Model(&models.Post{}).Where("series_id = ?", series.ID).Pluck("id", &existingIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch series posts"})
+		return
+	}
+
+	// The new order must list every post of the series exactly once
+	if !sameIDSet(existingIDs, req.PostIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "post_ids must contain each post of the series exactly once"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range req.PostIDs {
+			if err := tx.Model(&models.Post{}).
+				Where("id = ? AND series_id = ?", id, series.ID).
+				UpdateColumn("series_position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder series posts"})
+		return
+	}
+
+	respondWithSeriesPosts(c, db, series, "Series posts reordered successfully")
+}
+
+// respondWithSeriesPosts writes a series with all of its posts, drafts included
+func respondWithSeriesPosts(c *gin.Context, db *gorm.DB, series models.Series, message string) {
+	db.Preload("Posts", orderedSeriesPosts).First(&series, series.ID)
+	series.PostCount = int64(len(series.Posts))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"series":  series,
+	})
+}
+
+// renumberSeriesPosts rewrites the positions of a series' posts as 1..n in their current order
+func renumberSeriesPosts(tx *gorm.DB, seriesID uint) error {
+	var ids []uint
+	if err := orderedSeriesPosts(tx.Model(&models.Post{})).
+		Where("series_id = ?", seriesID).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		if err := tx.Model(&models.Post{}).Where("id = ?", id).UpdateColumn("series_position", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seriesNavigation returns the position of a published post among the
+// published posts of its series, or nil when it is not part of a series
+func seriesNavigation(db *gorm.DB, post *models.Post) (*SeriesNavigation, error) {
+	if post.SeriesID == nil {
+		return nil, nil
+	}
+
+	var series models.Series
+	if err := db.Preload("Posts", func(db *gorm.DB) *gorm.DB {
+		return publishedSeriesPosts(db).Select("id", "title", "slug", "series_id", "series_position")
+	}).First(&series, *post.SeriesID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	nav := &SeriesNavigation{
+		ID:    series.ID,
+		Title: series.Title,
+		Slug:  series.Slug,
+		Total: len(series.Posts),
+	}
+	for i, part := range series.Posts {
+		if part.ID != post.ID {
+			continue
+		}
+		nav.Position = i + 1
+		if i > 0 {
+			nav.Previous = seriesPostLink(series.Posts[i-1], i)
+		}
+		if i+1 < len(series.Posts) {
+			nav.Next = seriesPostLink(series.Posts[i+1], i+2)
+		}
+	}
+	return nav, nil
+}
+
+func seriesPostLink(post models.Post, position int) *SeriesPostLink {
+	return &SeriesPostLink{
+		ID:       post.ID,
+		Title:    post.Title,
+		Slug:     post.Slug,
+		Position: position,
+	}
+}
+
+// uniqueIDs drops repeated ids, keeping the first occurrence
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Entity types with a slug history
+const (
+	slugEntityPost     = "post"
+	slugEntityTag      = "tag"
+	slugEntityCategory = "category"
+)
+
+// slugPaths maps entity types to the API path their slugs are served under
+var slugPaths = map[string]string{
+	slugEntityPost:     "/api/v1/posts/",
+	slugEntityTag:      "/api/v1/tags/",
+	slugEntityCategory: "/api/v1/categories/",
+}
+
+// recordSlugChange keeps the previous slug of a renamed entity so it keeps
+// redirecting. A slug taken back into use stops redirecting.
+func recordSlugChange(tx *gorm.DB, entityType string, entityID uint, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	// Each old slug points at the entity that used it last
+	if err := tx.Where("entity_type = ? AND slug IN ?", entityType, []string{oldSlug, newSlug}).
+		Delete(&models.SlugHistory{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&models.SlugHistory{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Slug:       oldSlug,
+	}).Error
+}
+
+// redirectFromSlugHistory answers a lookup of a slug that is no longer in use
+// with a permanent redirect to the entity's current slug. currentSlug returns
+// the slug of the entity with the given id if it can still be served. It
+// reports whether a redirect was written.
+func redirectFromSlugHistory(c *gin.Context, db *gorm.DB, entityType, slug string, currentSlug func(id uint) (string, error)) bool {
+	var history models.SlugHistory
+	if err := db.Where("entity_type = ? AND slug = ?", entityType, slug).First(&history).Error; err != nil {
+		return false
+	}
+
+	current, err := currentSlug(history.EntityID)
+	if err != nil || current == "" || current == slug {
+		return false
+	}
+
+	location := slugPaths[entityType] + current
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	// The body lets clients that do not follow redirects update their own URLs
+	c.Header("Location", location)
+	c.JSON(http.StatusMovedPermanently, gin.H{
+		"message": "This " + entityType + " has moved",
+		"redirect": gin.H{
+			"type":     entityType,
+			"slug":     current,
+			"location": location,
+		},
+	})
+	return true
+}
@@ -1,8 +1,8 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strings"
 
 	"codewithdell/backend/internal/models"
 
@@ -32,6 +32,11 @@ func GetTags(c *gin.Context) {
 		return
 	}
 
+	if err := localizeTags(c, db, tags); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tag translations"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"tags":  tags,
 		"total": len(tags),
@@ -43,15 +48,47 @@ func GetTagBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	db := c.MustGet("db").(*gorm.DB)
 
+	locale, defaultLocale := requestLocale(c)
+
 	var tag models.Tag
-	if err := db.Preload("Posts").Preload("Projects").
-		Where("slug = ?", slug).
-		First(&tag).Error; err != nil {
+	findTag := func(column string, value interface{}) error {
+		return db.Preload("Posts", "status = ?", models.PostStatusPublished).
+			Preload("Projects", "status = ?", models.ProjectStatusPublished).
+			Where(column+" = ?", value).
+			First(&tag).Error
+	}
+
+	err := findTag("slug", slug)
+	if err != nil {
+		// A translated slug serves the tag in the translation's locale
+		if translation, ok := findTranslationBySlug(db, slugEntityTag, slug); ok {
+			err = findTag("id", translation.EntityID)
+			locale = translation.Locale
+		}
+	}
+	if err != nil {
+		// Links to a renamed tag redirect to its current slug
+		if redirectFromSlugHistory(c, db, slugEntityTag, slug, func(id uint) (string, error) {
+			var current models.Tag
+			err := db.Select("slug").First(&current, id).Error
+			return current.Slug, err
+		}) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, tag)
+	translations, err := loadTranslations(db, slugEntityTag, []uint{tag.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tag translations"})
+		return
+	}
+	localizeTag(&tag, translations[tag.ID], locale, defaultLocale)
+	c.Header("Content-Language", tag.Locale)
+	setAlternateLinks(c, tag.Alternates)
+
+	respondVersioned(c, tag.Version, tag)
 }
 
 // CreateTag handles creating a new tag (admin only)
@@ -86,7 +123,7 @@ func CreateTag(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	respondSaved(c, http.StatusCreated, tag.Version, gin.H{
 		"message": "Tag created successfully",
 		"tag":     tag,
 	})
@@ -109,6 +146,12 @@ func UpdateTag(c *gin.Context) {
 		return
 	}
 
+	if !requireIfMatch(c, tag.Version) {
+		return
+	}
+
+	previousSlug := tag.Slug
+
 	// Check if new name conflicts with existing tag
 	if req.Name != "" && req.Name != tag.Name {
 		var existingTag models.Tag
@@ -125,12 +168,27 @@ func UpdateTag(c *gin.Context) {
 		tag.Color = req.Color
 	}
 
-	if err := db.Save(&tag).Error; err != nil {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, &tag, tag.Version, map[string]interface{}{
+			"name":  tag.Name,
+			"slug":  tag.Slug,
+			"color": tag.Color,
+		}); err != nil {
+			return err
+		}
+		return recordSlugChange(tx, slugEntityTag, tag.ID, previousSlug, tag.Slug)
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "tags", tag.ID)
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update tag"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	tag.Version++
+	respondSaved(c, http.StatusOK, tag.Version, gin.H{
 		"message": "Tag updated successfully",
 		"tag":     tag,
 	})
@@ -147,6 +205,10 @@ func DeleteTag(c *gin.Context) {
 		return
 	}
 
+	if !requireIfMatch(c, tag.Version) {
+		return
+	}
+
 	// Check if tag has associated posts or projects
 	var postCount int64
 	db.Model(&models.Post{}).Joins("JOIN post_tags ON posts.id = post_tags.post_id").
@@ -165,7 +227,12 @@ func DeleteTag(c *gin.Context) {
 		return
 	}
 
-	if err := db.Delete(&tag).Error; err != nil {
+	err := deleteVersioned(db, &tag, tag.Version)
+	if errors.Is(err, errVersionConflict) {
+		respondStaleVersion(c, db, "tags", tag.ID)
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete tag"})
 		return
 	}
@@ -194,6 +261,17 @@ func GetTagPosts(c *gin.Context) {
 		return
 	}
 
+	listed := []models.Tag{tag}
+	if err := localizeTags(c, db, listed); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tag translations"})
+		return
+	}
+	tag = listed[0]
+	if err := localizePosts(c, db, posts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post translations"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"tag":   tag,
 		"posts": posts,
@@ -222,6 +300,17 @@ func GetTagProjects(c *gin.Context) {
 		return
 	}
 
+	listed := []models.Tag{tag}
+	if err := localizeTags(c, db, listed); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tag translations"})
+		return
+	}
+	tag = listed[0]
+	if err := localizeProjects(c, db, projects); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project translations"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"tag":      tag,
 		"projects": projects,
@@ -251,29 +340,3 @@ func GetPopularTags(c *gin.Context) {
 		"total": len(tags),
 	})
 }
-
-// generateSlug generates a URL-friendly slug from a string
-func generateSlug(input string) string {
-	// Convert to lowercase and replace spaces with hyphens
-	slug := strings.ToLower(input)
-	slug = strings.ReplaceAll(slug, " ", "-")
-	
-	// Remove special characters except hyphens
-	var result strings.Builder
-	for _, char := range slug {
-		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' {
-			result.WriteRune(char)
-		}
-	}
-	
-	// Remove multiple consecutive hyphens
-	slug = result.String()
-	for strings.Contains(slug, "--") {
-		slug = strings.ReplaceAll(slug, "--", "-")
-	}
-	
-	// Remove leading and trailing hyphens
-	slug = strings.Trim(slug, "-")
-	
-	return slug
-} 
\ No newline at end of file
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateTechnologyRequest represents technology creation request
+type CreateTechnologyRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	Description string `json:"description" binding:"max=500"`
+	Icon        string `json:"icon" binding:"max=100"`
+	Color       string `json:"color" binding:"max=7"`
+	Website     string `json:"website" binding:"omitempty,url"`
+}
+
+// UpdateTechnologyRequest represents technology update request
+type UpdateTechnologyRequest struct {
+	Name        string `json:"name" binding:"omitempty,min=1,max=50"`
+	Description string `json:"description" binding:"max=500"`
+	Icon        string `json:"icon" binding:"max=100"`
+	Color       string `json:"color" binding:"max=7"`
+	Website     string `json:"website" binding:"omitempty,url"`
+}
+
+// TechRadarEntry represents a technology with its published project usage
+type TechRadarEntry struct {
+	Technology   models.Technology                  `json:"technology"`
+	ProjectCount int64                              `json:"project_count"`
+	ByDifficulty map[models.ProjectDifficulty]int64 `json:"by_difficulty"`
+}
+
+// GetTechnologies handles getting all technologies
+func GetTechnologies(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var technologies []models.Technology
+	if err := db.Order("name ASC").Find(&technologies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch technologies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"technologies": technologies,
+		"total":        len(technologies),
+	})
+}
+
+// GetTechnologyBySlug handles getting a single technology by slug
+func GetTechnologyBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var technology models.Technology
+	if err := db.Preload("Projects", "status = ?", models.ProjectStatusPublished).
+		Where("slug = ?", slug).
+		First(&technology).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Technology not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, technology)
+}
+
+// GetTechnologyProjects handles getting projects by technology
+func GetTechnologyProjects(c *gin.Context) {
+	slug := c.Param("slug")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var technology models.Technology
+	if err := db.Where("slug = ?", slug).First(&technology).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Technology not found"})
+		return
+	}
+
+	var projects []models.Project
+	if err := db.Preload("Technologies").Preload("Tags").
+		Joins("JOIN project_technologies ON projects.id = project_technologies.project_id").
+		Where("project_technologies.technology_id = ? AND projects.status = ?", technology.ID, models.ProjectStatusPublished).
+		Order("projects.created_at DESC").
+		Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch technology projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"technology": technology,
+		"projects":   projects,
+		"total":      len(projects),
+	})
+}
+
+// GetTechRadar handles getting technology usage across published projects, grouped by difficulty
+func GetTechRadar(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var technologies []models.Technology
+	if err := db.Order("name ASC").Find(&technologies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch technologies"})
+		return
+	}
+
+	var rows []struct {
+		TechnologyID uint
+		Difficulty   models.ProjectDifficulty
+		Count        int64
+	}
+	if err := db.Table("project_technologies").
+		Select("project_technologies.technology_id, projects.difficulty, COUNT(DISTINCT projects.id) AS count").
+		Joins("JOIN projects ON projects.id = project_technologies.project_id").
+		Where("projects.status = ? AND projects.deleted_at IS NULL", models.ProjectStatusPublished).
+		Group("project_technologies.technology_id, projects.difficulty").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch technology usage"})
+		return
+	}
+
+	entries := make(map[uint]*TechRadarEntry, len(technologies))
+	radar := make([]*TechRadarEntry, 0, len(technologies))
+	for _, technology := range technologies {
+		entry := &TechRadarEntry{
+			Technology: technology,
+			ByDifficulty: map[models.ProjectDifficulty]int64{
+				models.DifficultyBeginner:     0,
+				models.DifficultyIntermediate: 0,
+				models.DifficultyAdvanced:     0,
+				models.DifficultyExpert:       0,
+			},
+		}
+		entries[technology.ID] = entry
+		radar = append(radar, entry)
+	}
+
+	for _, row := range rows {
+		if entry, ok := entries[row.TechnologyID]; ok {
+			entry.ByDifficulty[row.Difficulty] += row.Count
+			entry.ProjectCount += row.Count
+		}
+	}
+
+	// Most used technologies first, alphabetical within a tie
+	sort.SliceStable(radar, func(i, j int) bool {
+		return radar[i].ProjectCount > radar[j].ProjectCount
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"radar": radar,
+		"total": len(radar),
+	})
+}
+
+// CreateTechnology handles creating a new technology (admin only)
+func CreateTechnology(c *gin.Context) {
+	var req CreateTechnologyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Generate slug from name
+	slug := generateSlug(req.Name)
+
+	// Check if technology already exists
+	var existingTechnology models.Technology
+	if err := db.Where("name = ? OR slug = ?", req.Name, slug).First(&existingTechnology).Error; err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Technology already exists"})
+		return
+	}
+
+	technology := models.Technology{
+		Name:        req.Name,
+		Slug:        slug,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Color:       req.Color,
+		Website:     req.Website,
+	}
+
+	if err := db.Create(&technology).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create technology"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Technology created successfully",
+		"technology": technology,
+	})
+}
+
+// UpdateTechnology handles updating a technology (admin only)
+func UpdateTechnology(c *gin.Context) {
+	var req UpdateTechnologyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	technologyID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var technology models.Technology
+	if err := db.First(&technology, technologyID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Technology not found"})
+		return
+	}
+
+	// Check if new name conflicts with existing technology
+	if req.Name != "" && req.Name != technology.Name {
+		var existingTechnology models.Technology
+		if err := db.Where("name = ? AND id != ?", req.Name, technologyID).First(&existingTechnology).Error; err == nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Technology name already exists"})
+			return
+		}
+		technology.Name = req.Name
+		technology.Slug = generateSlug(req.Name)
+	}
+
+	// Update other fields
+	if req.Description != "" {
+		technology.Description = req.Description
+	}
+	if req.Icon != "" {
+		technology.Icon = req.Icon
+	}
+	if req.Color != "" {
+		technology.Color = req.Color
+	}
+	if req.Website != "" {
+		technology.Website = req.Website
+	}
+
+	if err := db.Save(&technology).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update technology"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Technology updated successfully",
+		"technology": technology,
+	})
+}
+
+// DeleteTechnology handles deleting a technology (admin only)
+func DeleteTechnology(c *gin.Context) {
+	technologyID := c.Param("id")
+	db := c.MustGet("db").(*gorm.DB)
+
+	var technology models.Technology
+	if err := db.First(&technology, technologyID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Technology not found"})
+		return
+	}
+
+	// Check if technology is used by any project
+	var projectCount int64
+	db.Model(&models.Project{}).Joins("JOIN project_technologies ON projects.id = project_technologies.project_id").
+		Where("project_technologies.technology_id = ?", technology.ID).Count(&projectCount)
+
+	if projectCount > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":         "Cannot delete technology used by projects",
+			"project_count": projectCount,
+		})
+		return
+	}
+
+	if err := db.Delete(&technology).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete technology"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Technology deleted successfully"})
+}
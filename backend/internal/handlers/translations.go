@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"codewithdell/backend/internal/i18n"
+	"codewithdell/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TranslationRequest represents a translation of a post, project, category or tag
+type TranslationRequest struct {
+	Title   string `json:"title" binding:"required,min=2,max=200"`
+	Slug    string `json:"slug" binding:"max=200"`
+	Content string `json:"content"`
+	Excerpt string `json:"excerpt"`
+}
+
+// translationTarget describes a translatable content type
+type translationTarget struct {
+	entityType string
+	label      string
+	table      string
+	path       string
+}
+
+// translationTargets maps the :type route parameter to content types
+var translationTargets = map[string]translationTarget{
+	"posts":      {entityType: "post", label: "Post", table: "posts", path: "/posts/"},
+	"projects":   {entityType: "project", label: "Project", table: "projects", path: "/projects/"},
+	"categories": {entityType: "category", label: "Category", table: "categories", path: "/categories/"},
+	"tags":       {entityType: "tag", label: "Tag", table: "tags", path: "/tags/"},
+}
+
+// GetTranslations handles listing the translations of a post, project, category or tag (admin only)
+func GetTranslations(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	target, id, ok := resolveTranslationTarget(c, db)
+	if !ok {
+		return
+	}
+
+	var translations []models.Translation
+	if err := db.Where("entity_type = ? AND entity_id = ?", target.entityType, id).
+		Order("locale ASC").
+		Find(&translations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"translations": translations,
+		"total":        len(translations),
+	})
+}
+
+// SaveTranslation handles creating or replacing the translation of a post,
+// project, category or tag in one locale (admin only)
+func SaveTranslation(c *gin.Context) {
+	var req TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	target, id, ok := resolveTranslationTarget(c, db)
+	if !ok {
+		return
+	}
+
+	locale, ok := translationLocale(c)
+	if !ok {
+		return
+	}
+
+	if req.Slug == "" {
+		req.Slug = generateSlug(req.Title)
+	}
+
+	// Translated slugs share the URL space of the content type
+	var conflicts int64
+	db.Table(target.table).Where("slug = ? AND id <> ? AND deleted_at IS NULL", req.Slug, id).Count(&conflicts)
+	if conflicts == 0 {
+		db.Model(&models.Translation{}).
+			Where("entity_type = ? AND slug = ? AND entity_id <> ?", target.entityType, req.Slug, id).
+			Count(&conflicts)
+	}
+	if conflicts > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": target.label + " with this slug already exists"})
+		return
+	}
+
+	translation := models.Translation{EntityType: target.entityType, EntityID: id, Locale: locale}
+	status := http.StatusOK
+	if err := db.Where(&translation).First(&translation).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		status = http.StatusCreated
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+
+	translation.Title = req.Title
+	translation.Slug = req.Slug
+	translation.Content = req.Content
+	translation.Excerpt = req.Excerpt
+
+	// Translations are part of the content, so they change its version
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&translation).Error; err != nil {
+			return err
+		}
+		return bumpVersion(tx, target.table, id)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"message":     "Translation saved successfully",
+		"translation": translation,
+	})
+}
+
+// DeleteTranslation handles removing the translation of a post, project,
+// category or tag in one locale (admin only)
+func DeleteTranslation(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	target, id, ok := resolveTranslationTarget(c, db)
+	if !ok {
+		return
+	}
+
+	var deleted int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("entity_type = ? AND entity_id = ? AND locale = ?", target.entityType, id, c.Param("locale")).
+			Delete(&models.Translation{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return bumpVersion(tx, target.table, id)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete translation"})
+		return
+	}
+	if deleted == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Translation deleted successfully"})
+}
+
+// resolveTranslationTarget reads the :type and :id route parameters, writing
+// an error response when they do not name existing content
+func resolveTranslationTarget(c *gin.Context, db *gorm.DB) (translationTarget, uint, bool) {
+	target, ok := translationTargets[c.Param("type")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid content type. Use posts, projects, categories or tags"})
+		return target, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + strings.ToLower(target.label) + " ID"})
+		return target, 0, false
+	}
+
+	var count int64
+	if err := db.Table(target.table).Where("id = ? AND deleted_at IS NULL", id).Count(&count).Error; err != nil || count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": target.label + " not found"})
+		return target, 0, false
+	}
+
+	return target, uint(id), true
+}
+
+// translationLocale reads the :locale route parameter, which must be a
+// supported locale other than the default one
+func translationLocale(c *gin.Context) (string, bool) {
+	_, defaultLocale := requestLocale(c)
+	for _, locale := range c.GetStringSlice("locales") {
+		if i18n.Normalize(locale) != i18n.Normalize(c.Param("locale")) {
+			continue
+		}
+		if locale == defaultLocale {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Content in the default locale is edited directly, not as a translation"})
+			return "", false
+		}
+		return locale, true
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported locale"})
+	return "", false
+}
+
+// requestLocale returns the negotiated locale of a request and the default locale
+func requestLocale(c *gin.Context) (string, string) {
+	defaultLocale := c.GetString("default_locale")
+	if defaultLocale == "" {
+		defaultLocale = "en"
+	}
+	locale := c.GetString("locale")
+	if locale == "" {
+		locale = defaultLocale
+	}
+	return locale, defaultLocale
+}
+
+// loadTranslations loads the translations of content of one type, keyed by content id
+func loadTranslations(db *gorm.DB, entityType string, ids []uint) (map[uint][]models.Translation, error) {
+	grouped := make(map[uint][]models.Translation)
+	if len(ids) == 0 {
+		return grouped, nil
+	}
+
+	var translations []models.Translation
+	if err := db.Where("entity_type = ? AND entity_id IN ?", entityType, ids).
+		Order("locale ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	for _, translation := range translations {
+		grouped[translation.EntityID] = append(grouped[translation.EntityID], translation)
+	}
+	return grouped, nil
+}
+
+// findTranslationBySlug returns the translation of a content type using slug
+func findTranslationBySlug(db *gorm.DB, entityType, slug string) (*models.Translation, bool) {
+	var translation models.Translation
+	if err := db.Where("entity_type = ? AND slug = ?", entityType, slug).First(&translation).Error; err != nil {
+		return nil, false
+	}
+	return &translation, true
+}
+
+// localize picks the translation to serve for locale, falling back to the
+// default locale, and lists the alternates of every available locale
+func localize(translations []models.Translation, locale, defaultLocale, baseSlug, path string) (*models.Translation, string, []models.Alternate) {
+	alternates := []models.Alternate{
+		{Hreflang: "x-default", Slug: baseSlug, Href: "/api/v1" + path + baseSlug},
+		{Hreflang: defaultLocale, Slug: baseSlug, Href: "/api/v1" + path + baseSlug + "?lang=" + defaultLocale},
+	}
+
+	var chosen *models.Translation
+	for i := range translations {
+		translation := &translations[i]
+		alternates = append(alternates, models.Alternate{
+			Hreflang: translation.Locale,
+			Slug:     translation.Slug,
+			Href:     "/api/v1" + path + translation.Slug + "?lang=" + translation.Locale,
+		})
+		if translation.Locale == locale && locale != defaultLocale {
+			chosen = translation
+		}
+	}
+
+	if chosen == nil {
+		return nil, defaultLocale, alternates
+	}
+	return chosen, locale, alternates
+}
+
+// setAlternateLinks advertises the alternates of a piece of content in Link headers
+func setAlternateLinks(c *gin.Context, alternates []models.Alternate) {
+	for _, alternate := range alternates {
+		c.Writer.Header().Add("Link", "<"+alternate.Href+`>; rel="alternate"; hreflang="`+alternate.Hreflang+`"`)
+	}
+}
+
+// localizePost serves a post in locale and returns the translation used, if any
+func localizePost(post *models.Post, translations []models.Translation, locale, defaultLocale string) *models.Translation {
+	translation, served, alternates := localize(translations, locale, defaultLocale, post.Slug, "/posts/")
+	if translation != nil {
+		post.Title = translation.Title
+		post.Slug = translation.Slug
+		if translation.Content != "" {
+			post.Content = translation.Content
+		}
+		if translation.Excerpt != "" {
+			post.Excerpt = translation.Excerpt
+		}
+	}
+	post.Locale, post.Alternates = served, alternates
+	return translation
+}
+
+// localizeProject serves a project in locale
+func localizeProject(project *models.Project, translations []models.Translation, locale, defaultLocale string) {
+	translation, served, alternates := localize(translations, locale, defaultLocale, project.Slug, "/projects/")
+	if translation != nil {
+		project.Title = translation.Title
+		project.Slug = translation.Slug
+		if translation.Content != "" {
+			project.Content = translation.Content
+		}
+		if translation.Excerpt != "" {
+			project.Description = translation.Excerpt
+		}
+	}
+	project.Locale, project.Alternates = served, alternates
+}
+
+// localizeCategory serves a category in locale
+func localizeCategory(category *models.Category, translations []models.Translation, locale, defaultLocale string) {
+	translation, served, alternates := localize(translations, locale, defaultLocale, category.Slug, "/categories/")
+	if translation != nil {
+		category.Name = translation.Title
+		category.Slug = translation.Slug
+		if translation.Excerpt != "" {
+			category.Description = translation.Excerpt
+		}
+	}
+	category.Locale, category.Alternates = served, alternates
+}
+
+// localizeTag serves a tag in locale
+func localizeTag(tag *models.Tag, translations []models.Translation, locale, defaultLocale string) {
+	translation, served, alternates := localize(translations, locale, defaultLocale, tag.Slug, "/tags/")
+	if translation != nil {
+		tag.Name = translation.Title
+		tag.Slug = translation.Slug
+	}
+	tag.Locale, tag.Alternates = served, alternates
+}
+
+// localizePosts serves a list of posts in the request locale
+func localizePosts(c *gin.Context, db *gorm.DB, posts []models.Post) error {
+	locale, defaultLocale := requestLocale(c)
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+	}
+	translations, err := loadTranslations(db, "post", ids)
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		localizePost(&posts[i], translations[posts[i].ID], locale, defaultLocale)
+	}
+	return nil
+}
+
+// localizeProjects serves a list of projects in the request locale
+func localizeProjects(c *gin.Context, db *gorm.DB, projects []models.Project) error {
+	locale, defaultLocale := requestLocale(c)
+	ids := make([]uint, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+	translations, err := loadTranslations(db, "project", ids)
+	if err != nil {
+		return err
+	}
+	for i := range projects {
+		localizeProject(&projects[i], translations[projects[i].ID], locale, defaultLocale)
+	}
+	return nil
+}
+
+// localizeCategories serves a list of categories in the request locale
+func localizeCategories(c *gin.Context, db *gorm.DB, categories []models.Category) error {
+	locale, defaultLocale := requestLocale(c)
+	ids := make([]uint, len(categories))
+	for i := range categories {
+		ids[i] = categories[i].ID
+	}
+	translations, err := loadTranslations(db, "category", ids)
+	if err != nil {
+		return err
+	}
+	for i := range categories {
+		localizeCategory(&categories[i], translations[categories[i].ID], locale, defaultLocale)
+	}
+	return nil
+}
+
+// localizeTags serves a list of tags in the request locale
+func localizeTags(c *gin.Context, db *gorm.DB, tags []models.Tag) error {
+	locale, defaultLocale := requestLocale(c)
+	ids := make([]uint, len(tags))
+	for i := range tags {
+		ids[i] = tags[i].ID
+	}
+	translations, err := loadTranslations(db, "tag", ids)
+	if err != nil {
+		return err
+	}
+	for i := range tags {
+		localizeTag(&tags[i], translations[tags[i].ID], locale, defaultLocale)
+	}
+	return nil
+}
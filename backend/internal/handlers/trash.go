@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"codewithdell/backend/internal/trash"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetTrashSummary handles counting the trashed items of every type (admin only)
+func GetTrashSummary(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	counts := gin.H{}
+	var total int64
+	for _, t := range trash.Types {
+		count, err := t.Count(db)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count trash"})
+			return
+		}
+		counts[t.Name] = count
+		total += count
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"counts": counts,
+		"total":  total,
+	})
+}
+
+// GetTrash handles listing the trashed items of one type, most recently
+// deleted first (admin only)
+func GetTrash(c *gin.Context) {
+	t, ok := trashType(c)
+	if !ok {
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	p := parsePagination(c)
+
+	items, total, err := t.List(db, p.Offset(), p.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trash"})
+		return
+	}
+
+	setPaginationHeaders(c, p, total, true)
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": total,
+		"page":  p.Page,
+		"limit": p.Limit,
+	})
+}
+
+// RestoreTrashItem handles restoring a trashed item together with the
+// deleted tags, categories and parents it refers to (admin only)
+func RestoreTrashItem(c *gin.Context) {
+	t, ok := trashType(c)
+	if !ok {
+		return
+	}
+	id, ok := trashItemID(c)
+	if !ok {
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	restored, err := t.Restore(db, id)
+	if err != nil {
+		respondTrashError(c, t, err, "Failed to restore item")
+		return
+	}
+
+	invalidateTrashed(c, t)
+	c.JSON(http.StatusOK, gin.H{
+		"message":  t.Label + " restored successfully",
+		"restored": restored,
+	})
+}
+
+// PurgeTrashItem handles permanently deleting a trashed item and everything
+// that refers to it (admin only)
+func PurgeTrashItem(c *gin.Context) {
+	t, ok := trashType(c)
+	if !ok {
+		return
+	}
+	id, ok := trashItemID(c)
+	if !ok {
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	if err := t.Purge(db, id); err != nil {
+		respondTrashError(c, t, err, "Failed to purge item")
+		return
+	}
+
+	invalidateTrashed(c, t)
+	c.JSON(http.StatusOK, gin.H{"message": t.Label + " permanently deleted"})
+}
+
+// trashType resolves the :type route parameter
+func trashType(c *gin.Context) (*trash.Type, bool) {
+	t, ok := trash.Lookup(c.Param("type"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown trash type"})
+	}
+	return t, ok
+}
+
+// trashItemID parses the :id route parameter
+func trashItemID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondTrashError maps errors of the trash package to responses
+func respondTrashError(c *gin.Context, t *trash.Type, err error, failure string) {
+	var conflict *trash.ConflictError
+	switch {
+	case errors.Is(err, trash.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": t.Label + " not found in trash"})
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, gin.H{"error": conflict.Reason})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+	}
+}
+
+// invalidateTrashed drops cached related content after posts or projects
+// come back from or leave the trash
+func invalidateTrashed(c *gin.Context, t *trash.Type) {
+	switch t.Name {
+	case "posts", "projects":
+		invalidateRelated(c.Request.Context(), t.EntityType)
+	}
+}
@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiate picks the locale to serve a request in. An explicit lang
+// parameter wins, then the Accept-Language entries by preference, and
+// fallback is used when nothing matches a supported locale.
+func Negotiate(lang, acceptLanguage string, supported []string, fallback string) string {
+	if locale, ok := Match(lang, supported); ok {
+		return locale
+	}
+
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale, ok := Match(tag, supported); ok {
+			return locale
+		}
+	}
+	return fallback
+}
+
+// Match returns the supported locale for a language tag. A regional tag such
+// as id-ID matches its base language when the region is not supported itself.
+func Match(tag string, supported []string) (string, bool) {
+	tag = Normalize(tag)
+	if tag == "" {
+		return "", false
+	}
+
+	base := tag
+	if i := strings.Index(tag, "-"); i > 0 {
+		base = tag[:i]
+	}
+
+	for _, locale := range supported {
+		if Normalize(locale) == tag {
+			return locale, true
+		}
+	}
+	for _, locale := range supported {
+		if Normalize(locale) == base {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Normalize lowercases a language tag and uses hyphens as separators
+func Normalize(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header
+// ordered by their quality value, dropping wildcards and rejected tags
+func parseAcceptLanguage(header string) []string {
+	type entry struct {
+		tag     string
+		quality float64
+	}
+
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			entries = append(entries, entry{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+
+	tags := make([]string, len(entries))
+	for i, e := range entries {
+		tags[i] = e.tag
+	}
+	return tags
+}
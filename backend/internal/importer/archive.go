@@ -0,0 +1,397 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Layout of the site export archive written by the exporter
+const (
+	ArchiveFormat    = "codewithdell-export"
+	ArchiveVersion   = 1
+	ManifestFile     = "manifest.json"
+	CategoriesFile   = "categories.json"
+	TagsFile         = "tags.json"
+	TechnologiesFile = "technologies.json"
+	ScreenshotsFile  = "screenshots.json"
+	PostsDir         = "posts"
+	ProjectsDir      = "projects"
+	UploadsDir       = "uploads"
+)
+
+// maxMediaSize bounds a single uploaded file restored from an archive
+const maxMediaSize = 50 * 1024 * 1024
+
+// Manifest identifies a site export and counts what it holds
+type Manifest struct {
+	Format     string         `json:"format"`
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// CategoryRecord is a category in a site export. Parent is the slug of the
+// parent category.
+type CategoryRecord struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+}
+
+// TagRecord is a tag in a site export
+type TagRecord struct {
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Color string `json:"color,omitempty"`
+}
+
+// TechnologyRecord is a technology in a site export
+type TechnologyRecord struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Website     string `json:"website,omitempty"`
+}
+
+// ScreenshotRecord is a project screenshot in a site export. Project is the
+// slug of the project.
+type ScreenshotRecord struct {
+	Project  string `json:"project"`
+	Title    string `json:"title,omitempty"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text,omitempty"`
+	Order    int    `json:"order"`
+}
+
+// RestoreSummary counts the records of a site export that were created or
+// changed, besides posts and projects
+type RestoreSummary struct {
+	Media        int `json:"media"`
+	Categories   int `json:"categories"`
+	Tags         int `json:"tags"`
+	Technologies int `json:"technologies"`
+	Screenshots  int `json:"screenshots"`
+}
+
+// readManifest returns the manifest of a site export, or nil when fsys is
+// not one
+func readManifest(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Format != ArchiveFormat {
+		return nil, nil
+	}
+	if manifest.Version > ArchiveVersion {
+		return nil, fmt.Errorf("export version %d is newer than the supported version %d", manifest.Version, ArchiveVersion)
+	}
+	return &manifest, nil
+}
+
+// restore brings the content of a site export into the database. Media and
+// taxonomies come first, so posts and projects find what they refer to.
+func (im *Importer) restore(fsys fs.FS, report *Report) error {
+	summary := &RestoreSummary{}
+	report.Restored = summary
+
+	var err error
+	if summary.Media, err = im.restoreMedia(fsys); err != nil {
+		return err
+	}
+	if summary.Tags, err = im.restoreTags(fsys); err != nil {
+		return err
+	}
+	if summary.Categories, err = im.restoreCategories(fsys); err != nil {
+		return err
+	}
+	if summary.Technologies, err = im.restoreTechnologies(fsys); err != nil {
+		return err
+	}
+
+	if isDir(fsys, PostsDir) {
+		if err := im.importPosts(fsys, PostsDir, report); err != nil {
+			return err
+		}
+	}
+
+	if !isDir(fsys, ProjectsDir) {
+		return nil
+	}
+	var screenshots []ScreenshotRecord
+	if err := readRecords(fsys, ScreenshotsFile, &screenshots); err != nil {
+		return err
+	}
+	byProject := make(map[string][]ScreenshotRecord)
+	for _, screenshot := range screenshots {
+		byProject[screenshot.Project] = append(byProject[screenshot.Project], screenshot)
+	}
+
+	files, err := markdownFiles(fsys, ProjectsDir)
+	if err != nil {
+		return err
+	}
+	slugs := make(map[string]string)
+	for _, file := range files {
+		report.add(im.importProject(fsys, file, slugs, byProject, summary))
+	}
+	return nil
+}
+
+// restoreMedia copies the uploaded files of an export into the upload
+// directory. Files that already exist with the same size are kept.
+func (im *Importer) restoreMedia(fsys fs.FS) (int, error) {
+	if !isDir(fsys, UploadsDir) {
+		return 0, nil
+	}
+
+	copied := 0
+	err := fs.WalkDir(fsys, UploadsDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxMediaSize {
+			return fmt.Errorf("%s is larger than %d bytes", name, maxMediaSize)
+		}
+
+		destination := filepath.Join(im.options.UploadDir, filepath.FromSlash(strings.TrimPrefix(name, UploadsDir+"/")))
+		if existing, err := os.Stat(destination); err == nil && existing.Size() == info.Size() {
+			return nil
+		}
+		copied++
+		if im.options.DryRun {
+			return nil
+		}
+		return copyFile(fsys, name, destination)
+	})
+	return copied, err
+}
+
+// restoreTags creates or updates the tags of an export
+func (im *Importer) restoreTags(fsys fs.FS) (int, error) {
+	var records []TagRecord
+	if err := readRecords(fsys, TagsFile, &records); err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, record := range records {
+		var tag models.Tag
+		found, err := findBySlug(im.db, record.Slug, &tag)
+		if err != nil {
+			return changed, err
+		}
+		if found && !tag.DeletedAt.Valid && tag.Name == record.Name && tag.Color == record.Color {
+			continue
+		}
+
+		changed++
+		if im.options.DryRun {
+			continue
+		}
+		tag.Name, tag.Slug, tag.Color = record.Name, record.Slug, record.Color
+		tag.DeletedAt = gorm.DeletedAt{}
+		tag.Version++
+		if err := im.db.Unscoped().Omit(clause.Associations).Save(&tag).Error; err != nil {
+			return changed, fmt.Errorf("failed to restore tag %q: %w", record.Slug, err)
+		}
+	}
+	return changed, nil
+}
+
+// restoreCategories creates or updates the categories of an export, then
+// rebuilds their hierarchy
+func (im *Importer) restoreCategories(fsys fs.FS) (int, error) {
+	var records []CategoryRecord
+	if err := readRecords(fsys, CategoriesFile, &records); err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	ids := make(map[string]uint)
+	var moved []CategoryRecord
+	for _, record := range records {
+		var category models.Category
+		found, err := findBySlug(im.db.Preload("Parent"), record.Slug, &category)
+		if err != nil {
+			return changed, err
+		}
+
+		parent := ""
+		if category.Parent != nil {
+			parent = category.Parent.Slug
+		}
+		if parent != record.Parent || !found {
+			moved = append(moved, record)
+		}
+		if found && !category.DeletedAt.Valid && parent == record.Parent &&
+			category.Name == record.Name &&
+			category.Description == record.Description &&
+			category.Color == record.Color &&
+			category.Icon == record.Icon {
+			ids[record.Slug] = category.ID
+			continue
+		}
+
+		changed++
+		if im.options.DryRun {
+			continue
+		}
+		category.Name, category.Slug = record.Name, record.Slug
+		category.Description, category.Color, category.Icon = record.Description, record.Color, record.Icon
+		category.DeletedAt = gorm.DeletedAt{}
+		category.Version++
+		if err := im.db.Unscoped().Omit(clause.Associations).Save(&category).Error; err != nil {
+			return changed, fmt.Errorf("failed to restore category %q: %w", record.Slug, err)
+		}
+		ids[record.Slug] = category.ID
+	}
+
+	if im.options.DryRun {
+		return changed, nil
+	}
+
+	// Parents are linked once every category of the export exists
+	for _, record := range moved {
+		var parentID *uint
+		if record.Parent != "" {
+			id, ok := ids[record.Parent]
+			if !ok {
+				var parent models.Category
+				found, err := findBySlug(im.db, record.Parent, &parent)
+				if err != nil {
+					return changed, err
+				}
+				if !found {
+					continue
+				}
+				id = parent.ID
+			}
+			parentID = &id
+		}
+		if err := im.db.Model(&models.Category{}).Where("id = ?", ids[record.Slug]).
+			UpdateColumn("parent_id", parentID).Error; err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// restoreTechnologies creates or updates the technologies of an export
+func (im *Importer) restoreTechnologies(fsys fs.FS) (int, error) {
+	var records []TechnologyRecord
+	if err := readRecords(fsys, TechnologiesFile, &records); err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, record := range records {
+		var technology models.Technology
+		found, err := findBySlug(im.db, record.Slug, &technology)
+		if err != nil {
+			return changed, err
+		}
+		if found && !technology.DeletedAt.Valid &&
+			technology.Name == record.Name &&
+			technology.Description == record.Description &&
+			technology.Icon == record.Icon &&
+			technology.Color == record.Color &&
+			technology.Website == record.Website {
+			continue
+		}
+
+		changed++
+		if im.options.DryRun {
+			continue
+		}
+		technology.Name, technology.Slug = record.Name, record.Slug
+		technology.Description, technology.Icon = record.Description, record.Icon
+		technology.Color, technology.Website = record.Color, record.Website
+		technology.DeletedAt = gorm.DeletedAt{}
+		if err := im.db.Unscoped().Omit(clause.Associations).Save(&technology).Error; err != nil {
+			return changed, fmt.Errorf("failed to restore technology %q: %w", record.Slug, err)
+		}
+	}
+	return changed, nil
+}
+
+// findBySlug loads the row with a slug into dest, including deleted rows,
+// and reports whether there is one
+func findBySlug(db *gorm.DB, slug string, dest interface{}) (bool, error) {
+	err := db.Unscoped().Where("slug = ?", slug).First(dest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// readRecords decodes a JSON file of an export into records. A missing file
+// leaves records empty.
+func readRecords(fsys fs.FS, name string, records interface{}) error {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, records); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// copyFile copies a file out of fsys
+func copyFile(fsys fs.FS, name, destination string) error {
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	src, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// isDir reports whether name is a directory in fsys
+func isDir(fsys fs.FS, name string) bool {
+	info, err := fs.Stat(fsys, name)
+	return err == nil && info.IsDir()
+}
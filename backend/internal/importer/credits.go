@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// credits are the author and contributors named in the front matter of a
+// post or project, resolved to users. authorID is zero when no author is
+// named or the named author has no account.
+type credits struct {
+	authorID     uint
+	contributors []models.Contributor
+}
+
+// resolveCredits finds the users named by the author and contributors front
+// matter fields, by email or username. It returns nil when neither field is
+// set. Names without an account are skipped with a warning on item.
+func (im *Importer) resolveCredits(doc *Document, item *ReportItem) (*credits, error) {
+	author := stringField(doc.Fields, "author")
+	entries, _ := doc.Fields["contributors"].([]interface{})
+	if author == "" && len(entries) == 0 {
+		return nil, nil
+	}
+
+	result := &credits{}
+	if author != "" {
+		id, err := im.findUser(author)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("author %q has no account, the importing user is credited instead", author))
+		}
+		result.authorID = id
+	}
+
+	for _, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := stringField(fields, "user", "email", "username")
+		if name == "" {
+			continue
+		}
+		id, err := im.findUser(name)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("contributor %q has no account and is skipped", name))
+			continue
+		}
+		role := models.ContributorRole(strings.ToLower(stringField(fields, "role")))
+		if role == "" {
+			role = models.ContributorRoleAuthor
+		}
+		result.contributors = append(result.contributors, models.Contributor{UserID: id, Role: role})
+	}
+	return result, nil
+}
+
+// findUser returns the id of the user with an email or username, or zero
+// when there is none
+func (im *Importer) findUser(name string) (uint, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	var user models.User
+	err := im.db.Where("LOWER(email) = ? OR LOWER(username) = ?", name, name).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// contributorRows returns the contributors of content written by authorID,
+// in the order of c. The author is listed first unless c lists them.
+func contributorRows(authorID uint, c *credits) []models.Contributor {
+	var rows []models.Contributor
+	seen := make(map[uint]bool)
+	if c != nil {
+		for _, contributor := range c.contributors {
+			if seen[contributor.UserID] {
+				continue
+			}
+			seen[contributor.UserID] = true
+			if contributor.UserID == authorID {
+				contributor.Role = models.ContributorRoleAuthor
+			}
+			rows = append(rows, contributor)
+		}
+	}
+	if !seen[authorID] {
+		rows = append([]models.Contributor{{UserID: authorID, Role: models.ContributorRoleAuthor}}, rows...)
+	}
+	for i := range rows {
+		rows[i].Position = i
+	}
+	return rows
+}
+
+// orderedContributors preloads contributors sorted by their credit position
+func orderedContributors(db *gorm.DB) *gorm.DB {
+	return db.Order("contributors.position ASC, contributors.id ASC")
+}
+
+// sameContributors reports whether existing holds the users and roles of
+// rows, in order
+func sameContributors(existing, rows []models.Contributor) bool {
+	if len(existing) != len(rows) {
+		return false
+	}
+	for i, contributor := range existing {
+		if contributor.UserID != rows[i].UserID || contributor.Role != rows[i].Role {
+			return false
+		}
+	}
+	return true
+}
+
+// saveContributors replaces the contributors of the post or project whose
+// id is stored in column with rows
+func saveContributors(tx *gorm.DB, column string, id uint, rows []models.Contributor) error {
+	if err := tx.Where(column+" = ?", id).Delete(&models.Contributor{}).Error; err != nil {
+		return err
+	}
+
+	contributors := make([]models.Contributor, len(rows))
+	for i, row := range rows {
+		contentID := id
+		contributors[i] = models.Contributor{UserID: row.UserID, Role: row.Role, Position: row.Position}
+		if column == "post_id" {
+			contributors[i].PostID = &contentID
+		} else {
+			contributors[i].ProjectID = &contentID
+		}
+	}
+	return tx.Omit("User").Create(&contributors).Error
+}
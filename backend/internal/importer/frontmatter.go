@@ -0,0 +1,237 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a Markdown file split into the front matter fields the
+// importer understands and the Markdown body. Fields holds the whole front
+// matter with lowercased keys.
+type Document struct {
+	Title      string
+	Slug       string
+	Date       *time.Time
+	Expires    *time.Time
+	Tags       []string
+	Categories []string
+	Draft      bool
+	Status     string
+	Summary    string
+	Image      string
+	Body       string
+	Fields     map[string]interface{}
+}
+
+// dateLayouts are the date formats used by Hugo and Jekyll front matter
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Parse splits a Markdown file into its front matter and body. YAML front
+// matter is fenced by --- lines and TOML front matter by +++ lines.
+func Parse(data []byte) (*Document, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	fields := map[string]interface{}{}
+	body := text
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		raw, rest, err := splitFrontMatter(text, "---")
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal([]byte(raw), &fields); err != nil {
+			return nil, fmt.Errorf("invalid YAML front matter: %w", err)
+		}
+		body = rest
+	case strings.HasPrefix(text, "+++\n"):
+		raw, rest, err := splitFrontMatter(text, "+++")
+		if err != nil {
+			return nil, err
+		}
+		if err := toml.Unmarshal([]byte(raw), &fields); err != nil {
+			return nil, fmt.Errorf("invalid TOML front matter: %w", err)
+		}
+		body = rest
+	}
+
+	// Keys are matched case-insensitively
+	normalized := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		normalized[strings.ToLower(key)] = value
+	}
+	fields = normalized
+
+	doc := &Document{
+		Title:      stringField(fields, "title"),
+		Slug:       stringField(fields, "slug"),
+		Tags:       listField(fields, "tags"),
+		Categories: listField(fields, "categories", "category"),
+		Status:     strings.ToLower(stringField(fields, "status")),
+		Summary:    stringField(fields, "summary", "description", "excerpt"),
+		Image:      imageField(fields),
+		Body:       strings.TrimLeft(body, "\n"),
+		Fields:     fields,
+	}
+
+	for _, key := range []string{"date", "publishdate"} {
+		if value, ok := fields[key]; ok {
+			date, err := parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			doc.Date = date
+			break
+		}
+	}
+
+	// Hugo calls the expiry time expiryDate
+	for _, key := range []string{"expires_at", "expirydate"} {
+		if value, ok := fields[key]; ok {
+			date, err := parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			doc.Expires = date
+			break
+		}
+	}
+
+	// Jekyll marks drafts with published: false
+	if draft, ok := fields["draft"].(bool); ok {
+		doc.Draft = draft
+	}
+	if published, ok := fields["published"].(bool); ok && !published {
+		doc.Draft = true
+	}
+
+	return doc, nil
+}
+
+// splitFrontMatter returns the front matter between the opening fence and
+// the next fence line, and the text after it
+func splitFrontMatter(text, fence string) (string, string, error) {
+	rest := text[len(fence)+1:]
+	if strings.HasPrefix(rest, fence+"\n") || rest == fence {
+		return "", strings.TrimPrefix(rest, fence), nil
+	}
+
+	end := strings.Index(rest, "\n"+fence+"\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n"+fence) {
+			return "", "", errors.New("front matter is not closed")
+		}
+		end = len(rest) - len(fence) - 1
+	}
+
+	after := end + len(fence) + 1
+	if after > len(rest) {
+		after = len(rest)
+	}
+	return rest[:end], rest[after:], nil
+}
+
+// stringField returns the first non-empty string value among keys
+func stringField(fields map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := fields[key]; ok && value != nil {
+			if text := strings.TrimSpace(fmt.Sprint(value)); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// listField returns the values of the first of keys that is present. Jekyll
+// allows a single string, separated by commas or spaces, in place of a list.
+func listField(fields map[string]interface{}, keys ...string) []string {
+	for _, key := range keys {
+		value, ok := fields[key]
+		if !ok || value == nil {
+			continue
+		}
+
+		var items []string
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+		case string:
+			if strings.Contains(v, ",") {
+				items = strings.Split(v, ",")
+			} else {
+				items = strings.Fields(v)
+			}
+		default:
+			items = []string{fmt.Sprint(v)}
+		}
+
+		var result []string
+		seen := make(map[string]bool)
+		for _, item := range items {
+			item = strings.TrimSpace(item)
+			if item == "" || seen[strings.ToLower(item)] {
+				continue
+			}
+			seen[strings.ToLower(item)] = true
+			result = append(result, item)
+		}
+		return result
+	}
+	return nil
+}
+
+// imageField returns the featured image, which Hugo themes often nest in a cover table
+func imageField(fields map[string]interface{}) string {
+	if image := stringField(fields, "featured_image", "image"); image != "" {
+		return image
+	}
+	if cover, ok := fields["cover"].(map[string]interface{}); ok {
+		return stringField(cover, "image")
+	}
+	return stringField(fields, "cover")
+}
+
+// parseDate converts a front matter date to a time
+func parseDate(value interface{}) (*time.Time, error) {
+	var date time.Time
+	switch v := value.(type) {
+	case time.Time:
+		date = v
+	case toml.LocalDateTime:
+		date = v.AsTime(time.UTC)
+	case toml.LocalDate:
+		date = v.AsTime(time.UTC)
+	case string:
+		text := strings.TrimSpace(v)
+		parsed := false
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				date, parsed = t, true
+				break
+			}
+		}
+		if !parsed {
+			return nil, fmt.Errorf("unrecognized date %q", text)
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized date %v", value)
+	}
+	return &date, nil
+}
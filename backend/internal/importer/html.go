@@ -0,0 +1,473 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// preBlockPattern matches preformatted blocks, whose blank lines are not paragraph breaks
+	preBlockPattern = regexp.MustCompile(`(?is)<pre[\s>].*?</pre>`)
+	// paragraphBreakPattern matches the blank lines WordPress turns into paragraphs
+	paragraphBreakPattern = regexp.MustCompile(`\n\s*\n`)
+	// blockStartPattern matches chunks that already start with a block element
+	blockStartPattern = regexp.MustCompile(`(?i)^\s*(<(?:/?(?:p|h[1-6]|ul|ol|li|pre|blockquote|div|table|figure|hr|dl|section|iframe|!--)[\s>/])|\x00)`)
+	// captionPattern matches the [caption] shortcode around an image and its caption
+	captionPattern = regexp.MustCompile(`(?s)\[caption[^\]]*\]\s*((?:<a[^>]*>)?\s*<img[^>]*>\s*(?:</a>)?)(.*?)\[/caption\]`)
+	// orderedMarkerPattern matches text that would start an ordered list
+	orderedMarkerPattern = regexp.MustCompile(`^\d{1,9}[.)] `)
+	// languageClassPattern captures the language of a code block class
+	languageClassPattern = regexp.MustCompile(`(?:^|\s)(?:language|lang)-([\w+#-]+)`)
+)
+
+// HTMLToMarkdown converts the HTML of a WordPress post or comment to
+// Markdown. Classic editor content keeps paragraphs as blank lines, which
+// are turned into paragraphs first the way WordPress displays them.
+func HTMLToMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = captionPattern.ReplaceAllString(source, "<figure>$1<figcaption>$2</figcaption></figure>")
+	if !strings.Contains(source, "<!-- wp:") {
+		source = autop(source)
+	}
+
+	body := &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: "body"}
+	nodes, err := html.ParseFragment(strings.NewReader(source), body)
+	if err != nil {
+		return source
+	}
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+	return strings.TrimSpace(renderBlocks(body)) + "\n"
+}
+
+// autop wraps the blank line separated chunks of text in paragraphs and
+// turns single line breaks into <br>, leaving block elements alone
+func autop(source string) string {
+	var pres []string
+	source = preBlockPattern.ReplaceAllStringFunc(source, func(block string) string {
+		pres = append(pres, block)
+		return fmt.Sprintf("\x00%d\x00", len(pres)-1)
+	})
+
+	chunks := paragraphBreakPattern.Split(strings.TrimSpace(source), -1)
+	for i, chunk := range chunks {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" || blockStartPattern.MatchString(chunk) {
+			chunks[i] = chunk
+			continue
+		}
+		chunks[i] = "<p>" + strings.ReplaceAll(chunk, "\n", "<br>\n") + "</p>"
+	}
+	source = strings.Join(chunks, "\n\n")
+
+	for i, block := range pres {
+		source = strings.Replace(source, fmt.Sprintf("\x00%d\x00", i), block, 1)
+	}
+	return source
+}
+
+// isBlock reports whether a node is rendered as a block of its own
+func isBlock(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.DataAtom {
+	case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.Ul, atom.Ol, atom.Pre, atom.Blockquote, atom.Hr, atom.Table,
+		atom.Div, atom.Section, atom.Article, atom.Aside, atom.Header, atom.Footer,
+		atom.Figure, atom.Figcaption, atom.Dl, atom.Iframe:
+		return true
+	}
+	return false
+}
+
+// renderBlocks renders the children of n as blocks separated by blank
+// lines. Runs of inline children become a paragraph.
+func renderBlocks(n *html.Node) string {
+	return joinBlocks(n, "\n\n")
+}
+
+// joinBlocks renders the children of n as blocks separated by separator
+func joinBlocks(n *html.Node, separator string) string {
+	var blocks []string
+	var inline strings.Builder
+	flush := func() {
+		if text := paragraph(inline.String()); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if !isBlock(child) {
+			inline.WriteString(renderInline(child))
+			continue
+		}
+		flush()
+		if block := strings.TrimSpace(renderBlock(child)); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	flush()
+	return strings.Join(blocks, separator)
+}
+
+// renderBlock renders a block element
+func renderBlock(n *html.Node) string {
+	switch n.DataAtom {
+	case atom.P:
+		return paragraph(renderChildren(n))
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.TrimSpace(renderChildren(n))
+		if text == "" {
+			return ""
+		}
+		level := int(n.Data[1] - '0')
+		return strings.Repeat("#", level) + " " + strings.ReplaceAll(text, "\n", " ")
+	case atom.Ul, atom.Ol:
+		return renderList(n)
+	case atom.Pre:
+		return renderCodeBlock(n)
+	case atom.Blockquote:
+		return prefixLines(renderBlocks(n), "> ", "> ")
+	case atom.Hr:
+		return "---"
+	case atom.Table:
+		return renderTable(n)
+	case atom.Figcaption:
+		if text := strings.TrimSpace(renderChildren(n)); text != "" {
+			return wrapInline(text, "*")
+		}
+		return ""
+	case atom.Iframe:
+		if src := attr(n, "src"); src != "" {
+			return "<" + src + ">"
+		}
+		return ""
+	}
+	return renderBlocks(n)
+}
+
+// renderInline renders a text node or inline element
+func renderInline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return escapeText(collapseSpace(n.Data))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\\\n"
+	case atom.Strong, atom.B:
+		return wrapInline(renderChildren(n), "**")
+	case atom.Em, atom.I, atom.Cite:
+		return wrapInline(renderChildren(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(renderChildren(n), "~~")
+	case atom.Code, atom.Kbd, atom.Tt:
+		return codeSpan(textContent(n))
+	case atom.A:
+		text := strings.TrimSpace(renderChildren(n))
+		href := attr(n, "href")
+		if href == "" {
+			return text
+		}
+		if text == "" {
+			text = escapeText(href)
+		}
+		return "[" + text + "](" + linkDestination(href) + ")"
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + escapeText(attr(n, "alt")) + "](" + linkDestination(src) + ")"
+	case atom.Script, atom.Style, atom.Noscript:
+		return ""
+	}
+	if isBlock(n) {
+		return " " + renderChildren(n) + " "
+	}
+	return renderChildren(n)
+}
+
+// renderChildren renders the children of n as inline content
+func renderChildren(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(renderInline(child))
+	}
+	return b.String()
+}
+
+// renderList renders an ordered or unordered list. Item content is
+// indented under its marker, so nested lists stay inside their item.
+func renderList(n *html.Node) string {
+	var items []string
+	number := 1
+	if start := attr(n, "start"); start != "" {
+		fmt.Sscanf(start, "%d", &number)
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		// Items without paragraphs keep nested lists right below their text
+		separator := "\n"
+		for node := child.FirstChild; node != nil; node = node.NextSibling {
+			if node.Type == html.ElementNode && node.DataAtom == atom.P {
+				separator = "\n\n"
+			}
+		}
+		content := joinBlocks(child, separator)
+		items = append(items, prefixLines(content, marker, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// renderCodeBlock renders a preformatted block as a fenced code block,
+// taking the language from a language-* class
+func renderCodeBlock(n *html.Node) string {
+	language := ""
+	for _, node := range []*html.Node{n, n.FirstChild} {
+		if node == nil || node.Type != html.ElementNode {
+			continue
+		}
+		if match := languageClassPattern.FindStringSubmatch(attr(node, "class")); match != nil {
+			language = match[1]
+		}
+	}
+
+	code := strings.Trim(textContent(n), "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+// renderTable renders a table as a GFM table, with the first row as header
+func renderTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				walk(child)
+				continue
+			}
+			var cells []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					text := strings.TrimSpace(collapseSpace(renderChildren(cell)))
+					cells = append(cells, strings.ReplaceAll(text, "|", "\\|"))
+				}
+			}
+			rows = append(rows, cells)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	line := func(cells []string) string {
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	separator := make([]string, columns)
+	for i := range separator {
+		separator[i] = "---"
+	}
+	lines := []string{line(rows[0]), line(separator)}
+	for _, row := range rows[1:] {
+		lines = append(lines, line(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// paragraph tidies rendered inline content into a paragraph, dropping the
+// spaces around line breaks and breaks at its ends
+func paragraph(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\\\n") {
+		if line = strings.TrimSpace(line); line != "" || len(lines) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return escapeBlockStart(strings.Join(lines, "\\\n"))
+}
+
+// prefixLines puts first in front of the first line of text and rest in
+// front of the others. Blank lines stay blank unless rest is a quote marker.
+func prefixLines(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" && strings.TrimSpace(prefix) == "" {
+			continue
+		}
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapInline wraps text in an emphasis marker, keeping surrounding space
+// outside of it so the Markdown stays valid
+func wrapInline(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+// codeSpan renders text as inline code, with a fence longer than any run of
+// backticks in it
+func codeSpan(text string) string {
+	text = collapseSpace(text)
+	if strings.TrimSpace(text) == "" {
+		return text
+	}
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+// linkDestination makes a URL safe to use as a link destination
+func linkDestination(url string) string {
+	url = strings.TrimSpace(url)
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
+
+// collapseSpace turns runs of whitespace into single spaces, as browsers do
+func collapseSpace(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range text {
+		// Non-breaking spaces are kept, as they are meant to stay
+		if unicode.IsSpace(r) && r != '\u00a0' {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		b.WriteRune(r)
+		space = false
+	}
+	return b.String()
+}
+
+// escapeText escapes the characters Markdown would read as formatting.
+// Underscores inside words are left alone, as they never emphasize.
+func escapeText(text string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	for i, r := range runes {
+		switch r {
+		case '\\', '*', '`', '[', ']':
+			b.WriteByte('\\')
+		case '_':
+			inWord := i > 0 && i < len(runes)-1 && isWordRune(runes[i-1]) && isWordRune(runes[i+1])
+			if !inWord {
+				b.WriteByte('\\')
+			}
+		case '<':
+			b.WriteString("&lt;")
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeBlockStart escapes a paragraph that would otherwise start a heading,
+// quote or list
+func escapeBlockStart(text string) string {
+	if text == "" {
+		return text
+	}
+	switch text[0] {
+	case '#', '>', '-', '+':
+		return "\\" + text
+	}
+	if match := orderedMarkerPattern.FindStringIndex(text); match != nil {
+		marker := match[1] - 2
+		return text[:marker] + "\\" + text[marker:]
+	}
+	return text
+}
+
+// isWordRune reports whether r is a letter or digit
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// textContent returns the text inside n without any markup
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == atom.Br {
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+// attr returns the value of an attribute of n
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxImageSize bounds a single imported image
+const maxImageSize = 10 * 1024 * 1024
+
+var (
+	// markdownImagePattern captures the target of ![alt](target "title")
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	// htmlImagePattern captures the src of <img> tags
+	htmlImagePattern = regexp.MustCompile(`<img\s[^>]*?src\s*=\s*["']([^"']+)["']`)
+)
+
+// imageExtensions are the image types accepted by the upload endpoint
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+}
+
+// importedImage is a local image referenced by a post and its upload URL
+type importedImage struct {
+	source string
+	url    string
+	data   []byte
+}
+
+// imageCollector resolves the local images referenced by one file
+type imageCollector struct {
+	fsys     fs.FS
+	file     string
+	images   map[string]*importedImage
+	warnings []string
+}
+
+// rewriteImages replaces the local image references in body with their
+// upload URLs, collecting the images to copy
+func (ic *imageCollector) rewriteImages(body string) string {
+	for _, pattern := range []*regexp.Regexp{markdownImagePattern, htmlImagePattern} {
+		body = replaceSubmatch(body, pattern, ic.resolve)
+	}
+	return body
+}
+
+// resolve returns the upload URL for an image reference, or the reference
+// itself when it is remote or cannot be imported
+func (ic *imageCollector) resolve(ref string) string {
+	if isRemote(ref) {
+		return ref
+	}
+
+	target := ref
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		target = target[:i]
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+
+	ext := strings.ToLower(path.Ext(target))
+	if !imageExtensions[ext] {
+		ic.warnings = append(ic.warnings, fmt.Sprintf("image %s skipped: unsupported format", ref))
+		return ref
+	}
+
+	// Root-relative paths point into the site, where Hugo serves static/
+	var candidates []string
+	if strings.HasPrefix(target, "/") {
+		candidates = []string{strings.TrimPrefix(target, "/"), "static" + target}
+	} else {
+		candidates = []string{path.Join(path.Dir(ic.file), target)}
+	}
+
+	reason := "not found"
+	for _, candidate := range candidates {
+		if image, ok := ic.images[candidate]; ok {
+			return image.url
+		}
+		if !fs.ValidPath(candidate) {
+			continue
+		}
+
+		data, err := readLimited(ic.fsys, candidate, maxImageSize)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				reason = err.Error()
+			}
+			continue
+		}
+
+		sum := sha256.Sum256(data)
+		image := &importedImage{
+			source: candidate,
+			url:    "/uploads/images/imported-" + hex.EncodeToString(sum[:8]) + ext,
+			data:   data,
+		}
+		ic.images[candidate] = image
+		return image.url
+	}
+
+	ic.warnings = append(ic.warnings, fmt.Sprintf("image %s skipped: %s", ref, reason))
+	return ref
+}
+
+// list returns the images collected so far
+func (ic *imageCollector) list() []*importedImage {
+	images := make([]*importedImage, 0, len(ic.images))
+	for _, image := range ic.images {
+		images = append(images, image)
+	}
+	return images
+}
+
+// copyImage writes an image into the upload directory. Images are named by
+// their content, so copying the same image again is a no-op.
+func copyImage(uploadDir string, image *importedImage) error {
+	dir := filepath.Join(uploadDir, "images")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	destination := filepath.Join(dir, path.Base(image.url))
+	if _, err := os.Stat(destination); err == nil {
+		return nil
+	}
+	return os.WriteFile(destination, image.data, 0644)
+}
+
+// isRemote reports whether an image reference points outside the import
+func isRemote(ref string) bool {
+	lower := strings.ToLower(ref)
+	return strings.HasPrefix(lower, "http://") ||
+		strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "//") ||
+		strings.HasPrefix(lower, "data:") ||
+		strings.HasPrefix(lower, "/uploads/")
+}
+
+// replaceSubmatch replaces the first capture group of every match of pattern
+func replaceSubmatch(text string, pattern *regexp.Regexp, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		b.WriteString(text[last:start])
+		b.WriteString(replace(text[start:end]))
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// readLimited reads a file from fsys, failing when it is larger than limit
+func readLimited(fsys fs.FS, name string, limit int64) ([]byte, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,306 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Actions reported for an imported file
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+	ActionError     = "error"
+)
+
+// maxDocumentSize bounds a single Markdown file
+const maxDocumentSize = 5 * 1024 * 1024
+
+var (
+	// datePrefixPattern matches the date Jekyll puts in front of post file names
+	datePrefixPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-`)
+	slugInvalidChars  = regexp.MustCompile("[^a-z0-9-]")
+	slugDashes        = regexp.MustCompile("-+")
+)
+
+// Options configures an import
+type Options struct {
+	// AuthorID is the user credited as the author of created posts and
+	// projects whose front matter names no author with an account
+	AuthorID uint
+	// UploadDir is the directory referenced images and archived media are copied into
+	UploadDir string
+	// DryRun reports what would change without writing anything
+	DryRun bool
+}
+
+// Report describes the outcome of an import
+type Report struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Restored  *RestoreSummary   `json:"restored,omitempty"`
+	WordPress *WordPressSummary `json:"wordpress,omitempty"`
+	Items     []ReportItem      `json:"items"`
+}
+
+// ReportItem describes the outcome for one Markdown file or WordPress post.
+// Comments counts the comments a WordPress post gained.
+type ReportItem struct {
+	File          string   `json:"file"`
+	Kind          string   `json:"kind"`
+	Action        string   `json:"action"`
+	ID            uint     `json:"id,omitempty"`
+	Slug          string   `json:"slug,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	Images        []string `json:"images,omitempty"`
+	NewTags       []string `json:"new_tags,omitempty"`
+	NewCategories []string `json:"new_categories,omitempty"`
+	Comments      int      `json:"comments,omitempty"`
+	Warnings      []string `json:"warnings,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// add records the outcome for one file
+func (r *Report) add(item ReportItem) {
+	switch item.Action {
+	case ActionCreate:
+		r.Created++
+	case ActionUpdate:
+		r.Updated++
+	case ActionUnchanged:
+		r.Unchanged++
+	default:
+		r.Failed++
+	}
+	r.Items = append(r.Items, item)
+}
+
+// Importer creates and updates posts from Markdown files with front matter
+// and WordPress exports, and restores site exports. Content is matched by
+// slug, so importing the same files again only applies what changed since.
+type Importer struct {
+	db      *gorm.DB
+	options Options
+}
+
+// New creates a new Markdown importer
+func New(db *gorm.DB, options Options) *Importer {
+	if options.UploadDir == "" {
+		options.UploadDir = "uploads"
+	}
+	return &Importer{db: db, options: options}
+}
+
+// Run imports every Markdown file in fsys as a post, or restores the site
+// export in fsys. A file that cannot be imported is reported and does not
+// stop the others.
+func (im *Importer) Run(fsys fs.FS) (*Report, error) {
+	report := &Report{DryRun: im.options.DryRun, Items: []ReportItem{}}
+
+	manifest, err := readManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		if err := im.restore(fsys, report); err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+
+	if err := im.importPosts(fsys, ".", report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// importPosts imports the Markdown files under dir as posts
+func (im *Importer) importPosts(fsys fs.FS, dir string, report *Report) error {
+	files, err := markdownFiles(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	slugs := make(map[string]string)
+	for _, file := range files {
+		report.add(im.importPost(fsys, file, slugs))
+	}
+	return nil
+}
+
+// prepare reads and parses a Markdown file and derives its slug. slugs maps
+// the slugs seen so far to their files, so two files cannot claim the same
+// post or project.
+func prepare(fsys fs.FS, file string, slugs map[string]string, item *ReportItem) (*Document, error) {
+	data, err := readLimited(fsys, file, maxDocumentSize)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Title == "" {
+		return nil, errors.New("front matter has no title")
+	}
+
+	nameSlug, nameDate := nameFromPath(file)
+	item.Title = doc.Title
+	item.Slug = Slugify(firstNonEmpty(doc.Slug, nameSlug, doc.Title))
+	if item.Slug == "" {
+		return nil, errors.New("no slug could be derived from the file")
+	}
+	if other, ok := slugs[item.Slug]; ok {
+		return nil, fmt.Errorf("slug %q is already used by %s", item.Slug, other)
+	}
+	slugs[item.Slug] = file
+
+	if doc.Date == nil {
+		doc.Date = nameDate
+	}
+	if hasPathElement(file, "_drafts") {
+		doc.Draft = true
+	}
+	return doc, nil
+}
+
+// publishState maps the status, draft flag and date of a document to a
+// status and publish time. Without an explicit status, future dates schedule
+// the content, and published content without a date keeps its publish date.
+func publishState(doc *Document, current *time.Time) (string, *time.Time) {
+	switch doc.Status {
+	case "draft", "archived":
+		return doc.Status, doc.Date
+	case "scheduled":
+		if doc.Date != nil {
+			return doc.Status, doc.Date
+		}
+		return "draft", nil
+	}
+
+	switch {
+	case doc.Draft:
+		return "draft", doc.Date
+	case doc.Status == "" && doc.Date != nil && doc.Date.After(time.Now()):
+		return "scheduled", doc.Date
+	case doc.Date != nil:
+		return "published", doc.Date
+	case current != nil:
+		return "published", current
+	}
+	now := time.Now()
+	return "published", &now
+}
+
+// markdownFiles lists the Markdown files under dir, skipping hidden
+// directories and Hugo section index pages
+func markdownFiles(fsys fs.FS, dir string) ([]string, error) {
+	var files []string
+	err := fs.WalkDir(fsys, dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		base := entry.Name()
+		if entry.IsDir() {
+			if name != dir && (strings.HasPrefix(base, ".") || base == "node_modules" || base == "__MACOSX") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		ext := strings.ToLower(path.Ext(base))
+		if ext != ".md" && ext != ".markdown" {
+			return nil
+		}
+		if strings.HasPrefix(base, ".") || strings.EqualFold(base, "_index.md") || strings.EqualFold(base, "README.md") {
+			return nil
+		}
+		files = append(files, name)
+		return nil
+	})
+	return files, err
+}
+
+// nameFromPath derives a slug and, for Jekyll posts, a date from a file
+// name. Hugo page bundles are named after their directory.
+func nameFromPath(file string) (string, *time.Time) {
+	name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+	if strings.EqualFold(name, "index") && path.Dir(file) != "." {
+		name = path.Base(path.Dir(file))
+	}
+
+	var date *time.Time
+	if match := datePrefixPattern.FindStringSubmatch(name); match != nil {
+		if t, err := time.Parse("2006-01-02", match[1]); err == nil {
+			date = &t
+			name = strings.TrimPrefix(name, match[0])
+		}
+	}
+	return name, date
+}
+
+// Slugify turns a title or file name into a slug
+func Slugify(text string) string {
+	slug := strings.ToLower(strings.TrimSpace(text))
+	slug = strings.ReplaceAll(slug, " ", "-")
+	slug = strings.ReplaceAll(slug, "_", "-")
+	slug = slugInvalidChars.ReplaceAllString(slug, "")
+	slug = slugDashes.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "-")
+}
+
+// hasPathElement reports whether a slash separated path contains element
+func hasPathElement(file, element string) bool {
+	for _, part := range strings.Split(file, "/") {
+		if part == element {
+			return true
+		}
+	}
+	return false
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// sameTimes reports whether two optional times are both unset or equal
+func sameTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// sameIDs reports whether two id lists hold the same ids in any order
+func sameIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[uint]int, len(a))
+	for _, id := range a {
+		counts[id]++
+	}
+	for _, id := range b {
+		if counts[id] == 0 {
+			return false
+		}
+		counts[id]--
+	}
+	return true
+}
@@ -0,0 +1,313 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"codewithdell/backend/internal/markdown"
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importPost creates or updates the post in one Markdown file
+func (im *Importer) importPost(fsys fs.FS, file string, slugs map[string]string) ReportItem {
+	item := ReportItem{File: file, Kind: "post"}
+	fail := func(err error) ReportItem {
+		item.Action = ActionError
+		item.Error = err.Error()
+		return item
+	}
+
+	doc, err := prepare(fsys, file, slugs, &item)
+	if err != nil {
+		return fail(err)
+	}
+
+	images := &imageCollector{fsys: fsys, file: file, images: make(map[string]*importedImage)}
+	content := images.rewriteImages(doc.Body)
+	featuredImage := ""
+	if doc.Image != "" {
+		featuredImage = images.resolve(doc.Image)
+	}
+	item.Warnings = images.warnings
+	for _, image := range images.list() {
+		item.Images = append(item.Images, image.source)
+	}
+	sort.Strings(item.Images)
+
+	existing, err := im.findPost(item.Slug)
+	if err != nil {
+		return fail(err)
+	}
+	credits, err := im.resolveCredits(doc, &item)
+	if err != nil {
+		return fail(err)
+	}
+
+	summary := markdown.Analyze(content)
+	excerpt := doc.Summary
+	if excerpt == "" {
+		excerpt = summary.Excerpt
+	}
+	var publishedAt *time.Time
+	expiresAt := doc.Expires
+	if existing != nil {
+		publishedAt = existing.PublishedAt
+		if expiresAt == nil {
+			expiresAt = existing.ExpiresAt
+		}
+	}
+	status, publishedAt := publishState(doc, publishedAt)
+
+	post := models.Post{
+		Title:         doc.Title,
+		Slug:          item.Slug,
+		Content:       content,
+		Excerpt:       excerpt,
+		FeaturedImage: featuredImage,
+		Status:        models.PostStatus(status),
+		PublishedAt:   publishedAt,
+		ExpiresAt:     expiresAt,
+		AuthorID:      im.options.AuthorID,
+		WordCount:     summary.WordCount,
+		ReadingTime:   summary.ReadingTime,
+	}
+
+	return im.savePost(item, existing, post, credits, doc.Tags, doc.Categories, func() error {
+		for _, image := range images.list() {
+			if err := copyImage(im.options.UploadDir, image); err != nil {
+				return fmt.Errorf("failed to copy image %s: %w", image.source, err)
+			}
+		}
+		return nil
+	})
+}
+
+// savePost creates post, or updates existing with it, and sets its tags and
+// categories. An author named in credits replaces the author of post, and
+// the contributors in credits replace those of existing; without credits an
+// existing post keeps its author and contributors. copyMedia, when given,
+// runs before anything is written, outside of dry runs.
+func (im *Importer) savePost(item ReportItem, existing *models.Post, post models.Post, credits *credits, tagNames, categoryNames []string, copyMedia func() error) ReportItem {
+	fail := func(err error) ReportItem {
+		item.Action = ActionError
+		item.Error = err.Error()
+		return item
+	}
+
+	// Missing tags and categories are only created outside of dry runs
+	tags, newTags, err := resolveTags(im.db, tagNames, false)
+	if err != nil {
+		return fail(err)
+	}
+	categories, newCategories, err := resolveCategories(im.db, categoryNames, false)
+	if err != nil {
+		return fail(err)
+	}
+	item.NewTags, item.NewCategories = newTags, newCategories
+
+	if existing != nil {
+		post.AuthorID = existing.AuthorID
+	}
+	if credits != nil && credits.authorID != 0 {
+		post.AuthorID = credits.authorID
+	}
+	contributors := contributorRows(post.AuthorID, credits)
+
+	switch {
+	case existing == nil:
+		item.Action = ActionCreate
+	case len(newTags) == 0 && len(newCategories) == 0 && samePost(existing, &post, tags, categories) &&
+		(credits == nil || sameContributors(existing.Contributors, contributors)):
+		item.Action = ActionUnchanged
+		item.ID = existing.ID
+		return item
+	default:
+		item.Action = ActionUpdate
+		item.ID = existing.ID
+		item.Slug = existing.Slug
+	}
+
+	if im.options.DryRun {
+		return item
+	}
+
+	if copyMedia != nil {
+		if err := copyMedia(); err != nil {
+			return fail(err)
+		}
+	}
+
+	err = im.db.Transaction(func(tx *gorm.DB) error {
+		tags, _, err := resolveTags(tx, tagNames, true)
+		if err != nil {
+			return err
+		}
+		categories, _, err := resolveCategories(tx, categoryNames, true)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			if err := tx.Omit(clause.Associations).Create(&post).Error; err != nil {
+				return err
+			}
+			if err := saveContributors(tx, "post_id", post.ID, contributors); err != nil {
+				return err
+			}
+			if err := recordRevision(tx, &post, post.AuthorID); err != nil {
+				return err
+			}
+		} else {
+			textChanged := existing.Title != post.Title || existing.Excerpt != post.Excerpt || existing.Content != post.Content
+			if textChanged {
+				if err := ensureBaselineRevision(tx, existing); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Model(existing).Updates(map[string]interface{}{
+				"title":          post.Title,
+				"content":        post.Content,
+				"excerpt":        post.Excerpt,
+				"featured_image": post.FeaturedImage,
+				"status":         post.Status,
+				"published_at":   post.PublishedAt,
+				"expires_at":     post.ExpiresAt,
+				"author_id":      post.AuthorID,
+				"word_count":     post.WordCount,
+				"reading_time":   post.ReadingTime,
+				"version":        gorm.Expr("version + 1"),
+			}).Error; err != nil {
+				return err
+			}
+			post.ID = existing.ID
+
+			if credits != nil {
+				if err := saveContributors(tx, "post_id", post.ID, contributors); err != nil {
+					return err
+				}
+			}
+
+			if textChanged {
+				if err := recordRevision(tx, &post, im.options.AuthorID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := replaceAssociation(tx, &post, "Tags", tags); err != nil {
+			return err
+		}
+		return replaceAssociation(tx, &post, "Categories", categories)
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	item.ID = post.ID
+	return item
+}
+
+// findPost returns the post a slug belongs to, following the slug history of
+// renamed posts. It returns nil when no post has the slug.
+func (im *Importer) findPost(slug string) (*models.Post, error) {
+	var post models.Post
+	err := im.db.Unscoped().Where("slug = ?", slug).First(&post).Error
+	if err == nil && post.DeletedAt.Valid {
+		return nil, fmt.Errorf("slug %q belongs to a deleted post", slug)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var history models.SlugHistory
+		err = im.db.Where("entity_type = ? AND slug = ?", "post", slug).First(&history).Error
+		if err == nil {
+			err = im.db.First(&post, history.EntityID).Error
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := im.db.Preload("Tags").Preload("Categories").
+		Preload("Contributors", orderedContributors).
+		First(&post, post.ID).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// samePost reports whether importing post would leave existing as it is
+func samePost(existing, post *models.Post, tags []models.Tag, categories []models.Category) bool {
+	return existing.Title == post.Title &&
+		existing.Content == post.Content &&
+		existing.Excerpt == post.Excerpt &&
+		existing.FeaturedImage == post.FeaturedImage &&
+		existing.Status == post.Status &&
+		existing.AuthorID == post.AuthorID &&
+		sameTimes(existing.PublishedAt, post.PublishedAt) &&
+		sameTimes(existing.ExpiresAt, post.ExpiresAt) &&
+		sameIDs(idsOf(existing.Tags, tagID), idsOf(tags, tagID)) &&
+		sameIDs(idsOf(existing.Categories, categoryID), idsOf(categories, categoryID))
+}
+
+// recordRevision saves the current state of a post as its next revision
+func recordRevision(tx *gorm.DB, post *models.Post, editorID uint) error {
+	var latest int
+	if err := tx.Model(&models.PostRevision{}).
+		Where("post_id = ?", post.ID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return err
+	}
+
+	revision := models.PostRevision{
+		PostID:   post.ID,
+		Version:  latest + 1,
+		Title:    post.Title,
+		Excerpt:  post.Excerpt,
+		Content:  post.Content,
+		EditorID: editorID,
+	}
+	return tx.Omit("Editor").Create(&revision).Error
+}
+
+// ensureBaselineRevision records the state of a post written before
+// revisions were tracked, so the import can be diffed and undone
+func ensureBaselineRevision(tx *gorm.DB, post *models.Post) error {
+	var count int64
+	if err := tx.Model(&models.PostRevision{}).Where("post_id = ?", post.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	revision := models.PostRevision{
+		PostID:    post.ID,
+		Version:   1,
+		Title:     post.Title,
+		Excerpt:   post.Excerpt,
+		Content:   post.Content,
+		EditorID:  post.AuthorID,
+		CreatedAt: post.UpdatedAt,
+	}
+	return tx.Omit("Editor").Create(&revision).Error
+}
+
+// tagID returns the id of a tag
+func tagID(tag models.Tag) uint {
+	return tag.ID
+}
+
+// categoryID returns the id of a category
+func categoryID(category models.Category) uint {
+	return category.ID
+}
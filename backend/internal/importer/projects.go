@@ -0,0 +1,279 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importProject creates or updates the project in one Markdown file of a
+// site export, together with its screenshots
+func (im *Importer) importProject(fsys fs.FS, file string, slugs map[string]string, screenshotsByProject map[string][]ScreenshotRecord, summary *RestoreSummary) ReportItem {
+	item := ReportItem{File: file, Kind: "project"}
+	fail := func(err error) ReportItem {
+		item.Action = ActionError
+		item.Error = err.Error()
+		return item
+	}
+
+	doc, err := prepare(fsys, file, slugs, &item)
+	if err != nil {
+		return fail(err)
+	}
+
+	screenshots := screenshotsByProject[item.Slug]
+	existing, err := im.findProject(item.Slug)
+	if err != nil {
+		return fail(err)
+	}
+	credits, err := im.resolveCredits(doc, &item)
+	if err != nil {
+		return fail(err)
+	}
+
+	var publishedAt *time.Time
+	if existing != nil {
+		publishedAt = existing.PublishedAt
+	}
+	status, publishedAt := publishState(doc, publishedAt)
+
+	// Without credits an existing project keeps its author and contributors
+	authorID := im.options.AuthorID
+	if existing != nil {
+		authorID = existing.AuthorID
+	}
+	if credits != nil && credits.authorID != 0 {
+		authorID = credits.authorID
+	}
+	contributors := contributorRows(authorID, credits)
+
+	project := models.Project{
+		Title:         doc.Title,
+		Slug:          item.Slug,
+		Description:   doc.Summary,
+		Content:       doc.Body,
+		FeaturedImage: doc.Image,
+		Status:        models.ProjectStatus(status),
+		PublishedAt:   publishedAt,
+		AuthorID:      authorID,
+		LiveURL:       stringField(doc.Fields, "live_url"),
+		SourceURL:     stringField(doc.Fields, "source_url"),
+		DemoURL:       stringField(doc.Fields, "demo_url"),
+		Difficulty:    models.ProjectDifficulty(firstNonEmpty(stringField(doc.Fields, "difficulty"), string(models.DifficultyIntermediate))),
+		Duration:      stringField(doc.Fields, "duration"),
+		TeamSize:      intField(doc.Fields, "team_size", 1),
+	}
+	technologyNames := listField(doc.Fields, "technologies")
+
+	// Missing tags, categories and technologies are only created outside of dry runs
+	tags, newTags, err := resolveTags(im.db, doc.Tags, false)
+	if err != nil {
+		return fail(err)
+	}
+	categories, newCategories, err := resolveCategories(im.db, doc.Categories, false)
+	if err != nil {
+		return fail(err)
+	}
+	technologies, newTechnologies, err := resolveTechnologies(im.db, technologyNames, false)
+	if err != nil {
+		return fail(err)
+	}
+	item.NewTags, item.NewCategories = newTags, newCategories
+
+	screenshotsChanged := existing == nil && len(screenshots) > 0 ||
+		existing != nil && !sameScreenshots(existing.Screenshots, screenshots)
+
+	switch {
+	case existing == nil:
+		item.Action = ActionCreate
+	case len(newTags) == 0 && len(newCategories) == 0 && len(newTechnologies) == 0 &&
+		!screenshotsChanged && sameProject(existing, &project, tags, categories, technologies) &&
+		(credits == nil || sameContributors(existing.Contributors, contributors)):
+		item.Action = ActionUnchanged
+		item.ID = existing.ID
+		return item
+	default:
+		item.Action = ActionUpdate
+		item.ID = existing.ID
+	}
+
+	if screenshotsChanged {
+		summary.Screenshots += len(screenshots)
+	}
+	if im.options.DryRun {
+		return item
+	}
+
+	err = im.db.Transaction(func(tx *gorm.DB) error {
+		tags, _, err := resolveTags(tx, doc.Tags, true)
+		if err != nil {
+			return err
+		}
+		categories, _, err := resolveCategories(tx, doc.Categories, true)
+		if err != nil {
+			return err
+		}
+		technologies, _, err := resolveTechnologies(tx, technologyNames, true)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			if err := tx.Omit(clause.Associations).Create(&project).Error; err != nil {
+				return err
+			}
+			if err := saveContributors(tx, "project_id", project.ID, contributors); err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(existing).Updates(map[string]interface{}{
+				"title":          project.Title,
+				"description":    project.Description,
+				"content":        project.Content,
+				"featured_image": project.FeaturedImage,
+				"status":         project.Status,
+				"published_at":   project.PublishedAt,
+				"author_id":      project.AuthorID,
+				"live_url":       project.LiveURL,
+				"source_url":     project.SourceURL,
+				"demo_url":       project.DemoURL,
+				"difficulty":     project.Difficulty,
+				"duration":       project.Duration,
+				"team_size":      project.TeamSize,
+				"version":        gorm.Expr("version + 1"),
+			}).Error; err != nil {
+				return err
+			}
+			project.ID = existing.ID
+
+			if credits != nil {
+				if err := saveContributors(tx, "project_id", project.ID, contributors); err != nil {
+					return err
+				}
+			}
+		}
+
+		if screenshotsChanged {
+			if err := tx.Unscoped().Where("project_id = ?", project.ID).Delete(&models.Screenshot{}).Error; err != nil {
+				return err
+			}
+			for _, record := range screenshots {
+				screenshot := models.Screenshot{
+					ProjectID: project.ID,
+					Title:     record.Title,
+					ImageURL:  record.ImageURL,
+					AltText:   record.AltText,
+					Order:     record.Order,
+				}
+				if err := tx.Omit(clause.Associations).Create(&screenshot).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := replaceAssociation(tx, &project, "Tags", tags); err != nil {
+			return err
+		}
+		if err := replaceAssociation(tx, &project, "Categories", categories); err != nil {
+			return err
+		}
+		return replaceAssociation(tx, &project, "Technologies", technologies)
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	item.ID = project.ID
+	return item
+}
+
+// findProject returns the project with a slug, or nil when there is none
+func (im *Importer) findProject(slug string) (*models.Project, error) {
+	var project models.Project
+	err := im.db.Unscoped().Where("slug = ?", slug).First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if project.DeletedAt.Valid {
+		return nil, fmt.Errorf("slug %q belongs to a deleted project", slug)
+	}
+
+	if err := im.db.Preload("Tags").Preload("Categories").Preload("Technologies").
+		Preload("Contributors", orderedContributors).
+		Preload("Screenshots", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"order" ASC, id ASC`)
+		}).
+		First(&project, project.ID).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// sameProject reports whether importing project would leave existing as it is
+func sameProject(existing, project *models.Project, tags []models.Tag, categories []models.Category, technologies []models.Technology) bool {
+	return existing.Title == project.Title &&
+		existing.Description == project.Description &&
+		existing.Content == project.Content &&
+		existing.FeaturedImage == project.FeaturedImage &&
+		existing.Status == project.Status &&
+		existing.AuthorID == project.AuthorID &&
+		sameTimes(existing.PublishedAt, project.PublishedAt) &&
+		existing.LiveURL == project.LiveURL &&
+		existing.SourceURL == project.SourceURL &&
+		existing.DemoURL == project.DemoURL &&
+		existing.Difficulty == project.Difficulty &&
+		existing.Duration == project.Duration &&
+		existing.TeamSize == project.TeamSize &&
+		sameIDs(idsOf(existing.Tags, tagID), idsOf(tags, tagID)) &&
+		sameIDs(idsOf(existing.Categories, categoryID), idsOf(categories, categoryID)) &&
+		sameIDs(idsOf(existing.Technologies, technologyID), idsOf(technologies, technologyID))
+}
+
+// sameScreenshots reports whether a project already has the screenshots of records, in order
+func sameScreenshots(existing []models.Screenshot, records []ScreenshotRecord) bool {
+	if len(existing) != len(records) {
+		return false
+	}
+	for i, screenshot := range existing {
+		record := records[i]
+		if screenshot.Title != record.Title ||
+			screenshot.ImageURL != record.ImageURL ||
+			screenshot.AltText != record.AltText ||
+			screenshot.Order != record.Order {
+			return false
+		}
+	}
+	return true
+}
+
+// intField returns a whole number front matter value, or fallback when it is missing
+func intField(fields map[string]interface{}, key string, fallback int) int {
+	switch v := fields[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+// technologyID returns the id of a technology
+func technologyID(technology models.Technology) uint {
+	return technology.ID
+}
@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OpenZip opens a zip archive for importing. An archive holding a single
+// top-level directory is opened at that directory, so root-relative image
+// paths resolve against the site inside it.
+func OpenZip(r io.ReaderAt, size int64) (fs.FS, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	root := ""
+	for _, file := range archive.File {
+		name := strings.TrimPrefix(file.Name, "/")
+		if strings.HasPrefix(name, "__MACOSX/") {
+			continue
+		}
+		top, _, nested := strings.Cut(name, "/")
+		if !nested || (root != "" && root != top) {
+			return archive, nil
+		}
+		root = top
+	}
+	if root == "" {
+		return archive, nil
+	}
+	return fs.Sub(archive, root)
+}
+
+// Open opens a directory or a zip archive for importing
+func Open(name string) (fs.FS, io.Closer, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		return os.DirFS(name), io.NopCloser(nil), nil
+	}
+	if strings.ToLower(filepath.Ext(name)) != ".zip" {
+		return nil, nil, fmt.Errorf("%s is neither a directory nor a zip archive", name)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	fsys, err := OpenZip(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return fsys, file, nil
+}
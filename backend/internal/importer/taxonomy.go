@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// taxonomy is a tag, category or technology, which imports refer to by name
+type taxonomy interface {
+	models.Tag | models.Category | models.Technology
+}
+
+// resolveNamed finds the tags, categories or technologies with the given
+// names or slugs, creating missing ones with build when create is set. It
+// also returns the names that have none yet.
+func resolveNamed[T taxonomy](db *gorm.DB, names []string, create bool, build func(name, slug string) T) ([]T, []string, error) {
+	var found []T
+	var missing []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		slug := Slugify(name)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		var row T
+		err := db.Unscoped().Where("slug = ? OR LOWER(name) = ?", slug, strings.ToLower(name)).First(&row).Error
+		switch {
+		case err == nil:
+			// Deleted rows still hold the name and slug, so they are brought back
+			if create {
+				if err := db.Unscoped().Model(&row).Where("deleted_at IS NOT NULL").Update("deleted_at", nil).Error; err != nil {
+					return nil, nil, err
+				}
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			missing = append(missing, name)
+			if !create {
+				continue
+			}
+			row = build(name, slug)
+			if err := db.Omit(clause.Associations).Create(&row).Error; err != nil {
+				return nil, nil, err
+			}
+		default:
+			return nil, nil, err
+		}
+		found = append(found, row)
+	}
+	return found, missing, nil
+}
+
+// resolveTags finds or creates the tags with the given names
+func resolveTags(db *gorm.DB, names []string, create bool) ([]models.Tag, []string, error) {
+	return resolveNamed(db, names, create, func(name, slug string) models.Tag {
+		return models.Tag{Name: name, Slug: slug}
+	})
+}
+
+// resolveCategories finds or creates the categories with the given names.
+// New categories are created at the top level.
+func resolveCategories(db *gorm.DB, names []string, create bool) ([]models.Category, []string, error) {
+	return resolveNamed(db, names, create, func(name, slug string) models.Category {
+		return models.Category{Name: name, Slug: slug}
+	})
+}
+
+// resolveTechnologies finds or creates the technologies with the given names
+func resolveTechnologies(db *gorm.DB, names []string, create bool) ([]models.Technology, []string, error) {
+	return resolveNamed(db, names, create, func(name, slug string) models.Technology {
+		return models.Technology{Name: name, Slug: slug}
+	})
+}
+
+// replaceAssociation sets the tags, categories or technologies of a post or project
+func replaceAssociation[T any](tx *gorm.DB, model interface{}, name string, values []T) error {
+	association := tx.Model(model).Association(name)
+	if len(values) == 0 {
+		return association.Clear()
+	}
+	return association.Replace(values)
+}
+
+// idsOf returns the ids of rows
+func idsOf[T any](rows []T, id func(T) uint) []uint {
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = id(row)
+	}
+	return ids
+}
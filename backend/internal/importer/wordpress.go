@@ -0,0 +1,722 @@
+package importer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"codewithdell/backend/internal/markdown"
+	"codewithdell/backend/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// wordpressMediaPrefix is the upload URL WordPress media is copied under
+const wordpressMediaPrefix = "/uploads/wordpress/"
+
+// wordpressUploadPattern matches links into the wp-content/uploads directory
+// of a WordPress site, capturing the host and the path below the directory
+var wordpressUploadPattern = regexp.MustCompile(`(?i)(?:(?:https?:)?//([^/\s"'<>()]+))?(?:/[^\s"'<>()]*?)?/wp-content/uploads/([^\s"'<>()?#]+)`)
+
+// usernameInvalidChars matches the characters left out of generated usernames
+var usernameInvalidChars = regexp.MustCompile("[^a-z0-9_]")
+
+// WordPressSummary counts what a WordPress import created besides posts
+type WordPressSummary struct {
+	Users      int `json:"users"`
+	Categories int `json:"categories"`
+	Tags       int `json:"tags"`
+	Comments   int `json:"comments"`
+	Media      int `json:"media"`
+}
+
+// wxrAuthor is a <wp:author> of a WordPress export
+type wxrAuthor struct {
+	ID          int    `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+	FirstName   string `xml:"author_first_name"`
+	LastName    string `xml:"author_last_name"`
+}
+
+// wxrCategory is a <wp:category> of a WordPress export. Parent is the slug
+// of the parent category.
+type wxrCategory struct {
+	Slug        string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"`
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+// wxrTag is a <wp:tag> of a WordPress export
+type wxrTag struct {
+	Name string `xml:"tag_name"`
+}
+
+// wxrItem is an <item> of a WordPress export: a post, page, attachment or
+// other post type
+type wxrItem struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Creator       string       `xml:"creator"`
+	Encoded       []wxrEncoded `xml:"encoded"`
+	ID            int          `xml:"post_id"`
+	Date          string       `xml:"post_date"`
+	DateGMT       string       `xml:"post_date_gmt"`
+	Name          string       `xml:"post_name"`
+	Status        string       `xml:"status"`
+	Type          string       `xml:"post_type"`
+	AttachmentURL string       `xml:"attachment_url"`
+	Terms         []wxrTerm    `xml:"category"`
+	Meta          []wxrMeta    `xml:"postmeta"`
+	Comments      []wxrComment `xml:"comment"`
+}
+
+// wxrEncoded is a <content:encoded> or <excerpt:encoded> element, told
+// apart by namespace
+type wxrEncoded struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+}
+
+// wxrTerm is a category or tag of an item
+type wxrTerm struct {
+	Domain string `xml:"domain,attr"`
+	Name   string `xml:",chardata"`
+}
+
+// wxrMeta is a <wp:postmeta> of an item
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// wxrComment is a <wp:comment> of an item
+type wxrComment struct {
+	ID          int    `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      int    `xml:"comment_parent"`
+	UserID      int    `xml:"comment_user_id"`
+}
+
+// content returns the <content:encoded> or <excerpt:encoded> text of an item
+func (item *wxrItem) content(excerpt bool) string {
+	for _, encoded := range item.Encoded {
+		if strings.Contains(encoded.XMLName.Space, "/excerpt/") == excerpt {
+			return encoded.Text
+		}
+	}
+	return ""
+}
+
+// wordpressImport holds the state of one WordPress import. The first pass
+// over the export collects its authors, taxonomies and attachments, which
+// are small, so the second pass can stream the posts one at a time.
+type wordpressImport struct {
+	*Importer
+	media       fs.FS
+	report      *Report
+	summary     *WordPressSummary
+	hosts       map[string]bool
+	authors     []wxrAuthor
+	categories  []wxrCategory
+	tags        []string
+	attachments map[int]string
+	users       map[string]uint
+	logins      map[string]uint
+	authorIDs   map[int]uint
+	copied      map[string]bool
+	slugs       map[string]string
+}
+
+// RunWordPress imports the posts of a WordPress export (WXR) with their
+// authors, categories, tags and approved comments. Content is converted from
+// HTML to Markdown and links to uploaded media point to the upload
+// directory, where media is copied from the wp-content/uploads directory in
+// media when one is given. The export is read twice and never held in memory
+// as a whole.
+func (im *Importer) RunWordPress(r io.ReadSeeker, media fs.FS) (*Report, error) {
+	summary := &WordPressSummary{}
+	w := &wordpressImport{
+		Importer:    im,
+		media:       media,
+		report:      &Report{DryRun: im.options.DryRun, WordPress: summary, Items: []ReportItem{}},
+		summary:     summary,
+		hosts:       make(map[string]bool),
+		attachments: make(map[int]string),
+		users:       make(map[string]uint),
+		logins:      make(map[string]uint),
+		authorIDs:   make(map[int]uint),
+		copied:      make(map[string]bool),
+		slugs:       make(map[string]string),
+	}
+
+	if err := w.scan(r, w.collect); err != nil {
+		return nil, err
+	}
+	if err := w.saveAuthors(); err != nil {
+		return nil, err
+	}
+	if err := w.saveTaxonomies(); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	err := w.scan(r, func(decoder *xml.Decoder, start xml.StartElement) error {
+		if start.Name.Local != "item" {
+			return nil
+		}
+		var item wxrItem
+		if err := decoder.DecodeElement(&item, &start); err != nil {
+			return err
+		}
+		if item.Type == "post" && item.Status != "trash" && item.Status != "auto-draft" {
+			w.report.add(w.importItem(&item))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w.report, nil
+}
+
+// scan calls handle for the elements of a WordPress export. Elements that
+// handle decodes are consumed, so their children are not seen.
+func (w *wordpressImport) scan(r io.Reader, handle func(decoder *xml.Decoder, start xml.StartElement) error) error {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid WordPress export: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if err := handle(decoder, start); err != nil {
+				return fmt.Errorf("invalid WordPress export: %w", err)
+			}
+		}
+	}
+}
+
+// collect records the site URLs, authors, taxonomies and attachments of an
+// export during the first pass
+func (w *wordpressImport) collect(decoder *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "link", "base_site_url", "base_blog_url":
+		var link string
+		if err := decoder.DecodeElement(&link, &start); err != nil {
+			return err
+		}
+		if parsed, err := url.Parse(strings.TrimSpace(link)); err == nil && parsed.Host != "" {
+			w.hosts[strings.ToLower(parsed.Host)] = true
+		}
+	case "author":
+		var author wxrAuthor
+		if err := decoder.DecodeElement(&author, &start); err != nil {
+			return err
+		}
+		w.authors = append(w.authors, author)
+	case "category":
+		var category wxrCategory
+		if err := decoder.DecodeElement(&category, &start); err != nil {
+			return err
+		}
+		w.categories = append(w.categories, category)
+	case "tag":
+		var tag wxrTag
+		if err := decoder.DecodeElement(&tag, &start); err != nil {
+			return err
+		}
+		w.tags = append(w.tags, html.UnescapeString(strings.TrimSpace(tag.Name)))
+	case "item":
+		var item struct {
+			ID            int    `xml:"post_id"`
+			Type          string `xml:"post_type"`
+			AttachmentURL string `xml:"attachment_url"`
+		}
+		if err := decoder.DecodeElement(&item, &start); err != nil {
+			return err
+		}
+		if item.Type == "attachment" && item.AttachmentURL != "" {
+			w.attachments[item.ID] = strings.TrimSpace(item.AttachmentURL)
+		}
+	}
+	return nil
+}
+
+// saveAuthors finds or creates a user for every author of the export
+func (w *wordpressImport) saveAuthors() error {
+	for _, author := range w.authors {
+		firstName, lastName := author.FirstName, author.LastName
+		if firstName == "" && lastName == "" {
+			firstName, lastName = splitName(author.DisplayName)
+		}
+		id, err := w.user(author.Email, firstNonEmpty(author.Login, author.DisplayName), firstName, lastName, models.RoleEditor)
+		if err != nil {
+			return fmt.Errorf("failed to import author %q: %w", author.Login, err)
+		}
+		w.logins[author.Login] = id
+		w.authorIDs[author.ID] = id
+	}
+	return nil
+}
+
+// saveTaxonomies creates the categories and tags of the export that do not
+// exist yet. Categories are listed parents first, so each new category is
+// linked to its parent as it is created.
+func (w *wordpressImport) saveTaxonomies() error {
+	ids := make(map[string]uint)
+	for _, record := range w.categories {
+		name := html.UnescapeString(strings.TrimSpace(record.Name))
+		slug := Slugify(firstNonEmpty(unescapePath(record.Slug), name))
+		if name == "" || slug == "" {
+			continue
+		}
+
+		// Existing categories are kept as they are, and brought back when deleted
+		var category models.Category
+		err := w.db.Unscoped().Where("slug = ? OR LOWER(name) = ?", slug, strings.ToLower(name)).First(&category).Error
+		if err == nil {
+			if category.DeletedAt.Valid && !w.options.DryRun {
+				if err := w.db.Unscoped().Model(&category).Update("deleted_at", nil).Error; err != nil {
+					return err
+				}
+			}
+			ids[record.Slug] = category.ID
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		w.summary.Categories++
+		if w.options.DryRun {
+			continue
+		}
+		category = models.Category{Name: name, Slug: slug, Description: html.UnescapeString(record.Description)}
+		if parentID, ok := ids[record.Parent]; ok && record.Parent != "" {
+			category.ParentID = &parentID
+		}
+		if err := w.db.Omit(clause.Associations).Create(&category).Error; err != nil {
+			return fmt.Errorf("failed to create category %q: %w", name, err)
+		}
+		ids[record.Slug] = category.ID
+	}
+
+	_, missing, err := resolveTags(w.db, w.tags, !w.options.DryRun)
+	if err != nil {
+		return err
+	}
+	w.summary.Tags = len(missing)
+	return nil
+}
+
+// importItem creates or updates a post from an item of the export, then
+// imports its approved comments
+func (w *wordpressImport) importItem(entry *wxrItem) ReportItem {
+	item := ReportItem{File: firstNonEmpty(strings.TrimSpace(entry.Link), "?p="+strconv.Itoa(entry.ID)), Kind: "post"}
+	fail := func(err error) ReportItem {
+		item.Action = ActionError
+		item.Error = err.Error()
+		return item
+	}
+
+	item.Title = html.UnescapeString(strings.TrimSpace(entry.Title))
+	if item.Title == "" {
+		return fail(errors.New("post has no title"))
+	}
+	item.Slug = Slugify(firstNonEmpty(unescapePath(entry.Name), item.Title))
+	if item.Slug == "" {
+		item.Slug = "post-" + strconv.Itoa(entry.ID)
+	}
+	if other, ok := w.slugs[item.Slug]; ok {
+		return fail(fmt.Errorf("slug %q is already used by %s", item.Slug, other))
+	}
+	w.slugs[item.Slug] = item.File
+
+	var media []string
+	content := HTMLToMarkdown(w.rewriteMedia(entry.content(false), &media))
+	featuredImage := ""
+	for _, meta := range entry.Meta {
+		if meta.Key != "_thumbnail_id" {
+			continue
+		}
+		id, _ := strconv.Atoi(strings.TrimSpace(meta.Value))
+		if attachment, ok := w.attachments[id]; ok {
+			featuredImage = w.rewriteMedia(attachment, &media)
+		}
+	}
+	item.Images, item.Warnings = w.copyMedia(media)
+
+	existing, err := w.findPost(item.Slug)
+	if err != nil {
+		return fail(err)
+	}
+
+	doc := &Document{Date: wordpressDate(entry.DateGMT, entry.Date)}
+	switch entry.Status {
+	case "future":
+		doc.Status = "scheduled"
+	case "publish":
+	case "private":
+		doc.Status = "draft"
+		item.Warnings = append(item.Warnings, "private post imported as a draft")
+	default:
+		doc.Status = "draft"
+	}
+	var publishedAt, expiresAt *time.Time
+	if existing != nil {
+		publishedAt = existing.PublishedAt
+		expiresAt = existing.ExpiresAt
+	}
+	status, publishedAt := publishState(doc, publishedAt)
+
+	summary := markdown.Analyze(content)
+	excerpt := summary.Excerpt
+	if text := entry.content(true); strings.TrimSpace(text) != "" {
+		excerpt = markdown.Analyze(HTMLToMarkdown(text)).Excerpt
+	}
+
+	authorID := w.options.AuthorID
+	if id, ok := w.logins[strings.TrimSpace(entry.Creator)]; ok && id != 0 {
+		authorID = id
+	}
+
+	var tags, categories []string
+	for _, term := range entry.Terms {
+		name := html.UnescapeString(strings.TrimSpace(term.Name))
+		switch term.Domain {
+		case "post_tag":
+			tags = append(tags, name)
+		case "category":
+			categories = append(categories, name)
+		}
+	}
+
+	post := models.Post{
+		Title:         item.Title,
+		Slug:          item.Slug,
+		Content:       content,
+		Excerpt:       excerpt,
+		FeaturedImage: featuredImage,
+		Status:        models.PostStatus(status),
+		PublishedAt:   publishedAt,
+		ExpiresAt:     expiresAt,
+		AuthorID:      authorID,
+		WordCount:     summary.WordCount,
+		ReadingTime:   summary.ReadingTime,
+	}
+	item = w.savePost(item, existing, post, nil, tags, categories, nil)
+	if item.Action == ActionError {
+		return item
+	}
+
+	comments, err := w.importComments(item.ID, entry.Comments)
+	if err != nil {
+		return fail(fmt.Errorf("failed to import comments: %w", err))
+	}
+	item.Comments = comments
+	return item
+}
+
+// importComments creates the approved comments of a post that were not
+// imported before. Replies to comments that were not approved are attached
+// to their closest approved ancestor. It returns the number of new comments.
+func (w *wordpressImport) importComments(postID uint, entries []wxrComment) (int, error) {
+	parents := make(map[int]int, len(entries))
+	approved := make(map[int]bool, len(entries))
+	var comments []wxrComment
+	for _, entry := range entries {
+		parents[entry.ID] = entry.Parent
+		if entry.Approved == "1" && (entry.Type == "" || entry.Type == "comment") {
+			approved[entry.ID] = true
+			comments = append(comments, entry)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].ID < comments[j].ID })
+
+	created := 0
+	ids := make(map[int]uint, len(comments))
+	for _, entry := range comments {
+		userID, err := w.commenter(&entry)
+		if err != nil {
+			return created, err
+		}
+		date := wordpressDate(entry.DateGMT, entry.Date)
+		if date == nil {
+			now := time.Now()
+			date = &now
+		}
+
+		// Comments are matched by post, author and date, so they are
+		// imported once however often the export is
+		if postID != 0 && userID != 0 {
+			var existing models.Comment
+			err := w.db.Unscoped().Where("post_id = ? AND user_id = ? AND created_at = ?", postID, userID, *date).First(&existing).Error
+			if err == nil {
+				ids[entry.ID] = existing.ID
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return created, err
+			}
+		}
+
+		created++
+		if w.options.DryRun {
+			continue
+		}
+
+		var parentID *uint
+		for parent := entry.Parent; parent != 0; parent = parents[parent] {
+			if approved[parent] {
+				if id, ok := ids[parent]; ok {
+					parentID = &id
+				}
+				break
+			}
+		}
+
+		comment := models.Comment{
+			Content:   strings.TrimSpace(HTMLToMarkdown(entry.Content)),
+			UserID:    userID,
+			PostID:    &postID,
+			ParentID:  parentID,
+			Status:    models.CommentStatusApproved,
+			CreatedAt: *date,
+			UpdatedAt: *date,
+		}
+		if err := w.db.Omit(clause.Associations).Create(&comment).Error; err != nil {
+			return created, err
+		}
+		ids[entry.ID] = comment.ID
+	}
+
+	w.summary.Comments += created
+	return created, nil
+}
+
+// commenter returns the user a comment is credited to: the author it was
+// written by, or a user created for its name and email
+func (w *wordpressImport) commenter(entry *wxrComment) (uint, error) {
+	if id, ok := w.authorIDs[entry.UserID]; ok && entry.UserID != 0 {
+		return id, nil
+	}
+
+	name := html.UnescapeString(strings.TrimSpace(entry.Author))
+	email := strings.TrimSpace(entry.AuthorEmail)
+	if email == "" {
+		// Anonymous commenters are told apart by name
+		email = firstNonEmpty(Slugify(name), "anonymous") + "@wordpress.invalid"
+	}
+	firstName, lastName := splitName(name)
+	return w.user(email, firstNonEmpty(name, "anonymous"), firstName, lastName, models.RoleUser)
+}
+
+// user returns the id of the user with an email, creating one when there is
+// none. Created users get a random password, so they sign in by resetting
+// it. In dry runs it returns 0 for users that would be created.
+func (w *wordpressImport) user(email, username, firstName, lastName string, role models.UserRole) (uint, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if id, ok := w.users[email]; ok {
+		return id, nil
+	}
+
+	var user models.User
+	err := w.db.Unscoped().Where("LOWER(email) = ?", email).First(&user).Error
+	if err == nil {
+		w.users[email] = user.ID
+		return user.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	w.summary.Users++
+	w.users[email] = 0
+	if w.options.DryRun {
+		return 0, nil
+	}
+
+	username, err = w.freeUsername(username)
+	if err != nil {
+		return 0, err
+	}
+	password, err := unusablePassword()
+	if err != nil {
+		return 0, err
+	}
+	user = models.User{
+		Email:     email,
+		Username:  username,
+		Password:  password,
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      role,
+		Status:    models.StatusActive,
+	}
+	if err := w.db.Omit(clause.Associations).Create(&user).Error; err != nil {
+		return 0, err
+	}
+	w.users[email] = user.ID
+	return user.ID, nil
+}
+
+// freeUsername derives a username that is not taken yet from a login or name
+func (w *wordpressImport) freeUsername(name string) (string, error) {
+	base := usernameInvalidChars.ReplaceAllString(strings.ToLower(strings.ReplaceAll(name, " ", "_")), "")
+	for len(base) < 3 {
+		base += "_"
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		var count int64
+		if err := w.db.Unscoped().Model(&models.User{}).Where("username = ?", candidate).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		candidate = base + strconv.Itoa(i)
+	}
+}
+
+// rewriteMedia points links into the uploads directory of the exported site
+// to the upload directory, adding the files they refer to to media
+func (w *wordpressImport) rewriteMedia(text string, media *[]string) string {
+	return wordpressUploadPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := wordpressUploadPattern.FindStringSubmatch(link)
+		host, file := strings.ToLower(match[1]), unescapePath(match[2])
+		if host != "" && len(w.hosts) > 0 && !w.hosts[host] {
+			return link
+		}
+		if !fs.ValidPath(file) {
+			return link
+		}
+		*media = append(*media, file)
+		return wordpressMediaPrefix + match[2]
+	})
+}
+
+// copyMedia copies media files from the uploads directory of the exported
+// site. It returns the files referenced and warnings for the ones missing.
+func (w *wordpressImport) copyMedia(files []string) ([]string, []string) {
+	var listed, warnings []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+		listed = append(listed, file)
+		if w.media == nil || w.copied[file] {
+			continue
+		}
+		w.copied[file] = true
+
+		source := ""
+		for _, candidate := range []string{file, "uploads/" + file, "wp-content/uploads/" + file} {
+			if info, err := fs.Stat(w.media, candidate); err == nil && !info.IsDir() {
+				if info.Size() > maxMediaSize {
+					break
+				}
+				source = candidate
+				break
+			}
+		}
+		if source == "" {
+			warnings = append(warnings, fmt.Sprintf("media %s not found or too large", file))
+			continue
+		}
+
+		destination := filepath.Join(w.options.UploadDir, "wordpress", filepath.FromSlash(file))
+		if _, err := os.Stat(destination); err == nil {
+			continue
+		}
+		w.summary.Media++
+		if w.options.DryRun {
+			continue
+		}
+		if err := copyFile(w.media, source, destination); err != nil {
+			warnings = append(warnings, fmt.Sprintf("media %s not copied: %s", file, err))
+		}
+	}
+	sort.Strings(listed)
+	return listed, warnings
+}
+
+// wordpressDate parses the GMT date of a post or comment, falling back to
+// its local date for drafts, which have no GMT date yet
+func wordpressDate(gmt, local string) *time.Time {
+	for _, value := range []string{gmt, local} {
+		value = strings.TrimSpace(value)
+		if value == "" || strings.HasPrefix(value, "0000") {
+			continue
+		}
+		if date, err := time.Parse("2006-01-02 15:04:05", value); err == nil {
+			return &date
+		}
+	}
+	return nil
+}
+
+// splitName splits a display name into a first and last name
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if i := strings.IndexByte(name, ' '); i >= 0 {
+		return name[:i], strings.TrimSpace(name[i+1:])
+	}
+	return name, ""
+}
+
+// unescapePath decodes a percent encoded slug or path, as WordPress stores
+// non-ASCII slugs
+func unescapePath(value string) string {
+	value = strings.TrimSpace(value)
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// unusablePassword returns the hash of a random password nobody knows. The
+// hash is only there to satisfy the schema, so the cheapest cost is used.
+func unusablePassword() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(secret)), bcrypt.MinCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ArchivedItem identifies a post moved from published to archived and why
+type ArchivedItem struct {
+	ID     uint
+	Slug   string
+	Reason string `gorm:"-"`
+}
+
+// Archiver archives published posts whose expiry time has passed or that
+// match an enabled archive rule, writing an audit entry for each of them
+type Archiver struct {
+	db *gorm.DB
+}
+
+// NewArchiver creates a new content archiver
+func NewArchiver(db *gorm.DB) *Archiver {
+	return &Archiver{db: db}
+}
+
+// Run archives all due posts. Like the publisher, it holds an advisory lock
+// so only one replica works per tick, and only published posts are updated,
+// so a post is never archived twice.
+func (a *Archiver) Run(ctx context.Context) error {
+	var archived []ArchivedItem
+
+	_, err := withAdvisoryLock(ctx, a.db, "jobs:archive-content", func(tx *gorm.DB) error {
+		archived = nil
+		now := time.Now()
+
+		expired, err := archivePosts(tx, "expires_at <= ?", now)
+		if err != nil {
+			return err
+		}
+		archived = append(archived, withReason(expired, "expired")...)
+
+		var rules []models.ArchiveRule
+		if err := tx.Where("enabled = ?", true).Order("id ASC").Find(&rules).Error; err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			condition, args := "published_at < ?", []interface{}{rule.Cutoff(now)}
+			if rule.CategoryID != nil {
+				condition += " AND id IN (SELECT post_id FROM post_categories WHERE category_id = ?)"
+				args = append(args, *rule.CategoryID)
+			}
+			matched, err := archivePosts(tx, condition, args...)
+			if err != nil {
+				return err
+			}
+			archived = append(archived, withReason(matched, "rule: "+rule.Name)...)
+		}
+
+		if len(archived) == 0 {
+			return nil
+		}
+		entries := make([]models.AuditEntry, 0, len(archived))
+		for _, item := range archived {
+			entries = append(entries, models.AuditEntry{
+				EntityType: "post",
+				EntityID:   item.ID,
+				Action:     models.AuditActionArchive,
+				Reason:     item.Reason,
+			})
+		}
+		return tx.Create(&entries).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range archived {
+		log.Info().Uint("post_id", item.ID).Str("slug", item.Slug).Str("reason", item.Reason).Msg("Archived post")
+	}
+
+	return nil
+}
+
+// archivePosts archives the published posts matching condition
+func archivePosts(tx *gorm.DB, condition string, args ...interface{}) ([]ArchivedItem, error) {
+	var items []ArchivedItem
+	err := tx.Raw(`UPDATE posts SET status = ?, version = version + 1, updated_at = NOW()
+		WHERE status = ? AND deleted_at IS NULL AND `+condition+`
+		RETURNING id, slug`,
+		append([]interface{}{models.PostStatusArchived, models.PostStatusPublished}, args...)...).
+		Scan(&items).Error
+	return items, err
+}
+
+// withReason sets the reason of archived items
+func withReason(items []ArchivedItem, reason string) []ArchivedItem {
+	for i := range items {
+		items[i].Reason = reason
+	}
+	return items
+}
@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+// withAdvisoryLock runs fn inside a transaction holding a Postgres advisory
+// lock for name. When another replica already holds the lock, fn is skipped
+// and ran is false. The lock is released when the transaction ends.
+func withAdvisoryLock(ctx context.Context, db *gorm.DB, name string, fn func(tx *gorm.DB) error) (ran bool, err error) {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	key := int64(h.Sum64())
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		ran = true
+		return fn(tx)
+	})
+	return ran, err
+}
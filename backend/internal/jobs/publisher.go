@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// PublishedItem identifies content flipped from scheduled to published
+type PublishedItem struct {
+	ID   uint
+	Slug string
+}
+
+// Publisher publishes scheduled posts and projects once their publish time has passed
+type Publisher struct {
+	db *gorm.DB
+}
+
+// NewPublisher creates a new scheduled content publisher
+func NewPublisher(db *gorm.DB) *Publisher {
+	return &Publisher{db: db}
+}
+
+// Run publishes all due content. It is safe to run concurrently on several
+// replicas: an advisory lock lets one replica do the work per tick, and the
+// conditional UPDATE never publishes the same row twice.
+func (p *Publisher) Run(ctx context.Context) error {
+	var posts, projects []PublishedItem
+
+	_, err := withAdvisoryLock(ctx, p.db, "jobs:publish-scheduled", func(tx *gorm.DB) error {
+		if err := tx.Raw(`UPDATE posts SET status = ?, version = version + 1, updated_at = NOW()
+			WHERE status = ? AND published_at <= NOW() AND deleted_at IS NULL
+			RETURNING id, slug`,
+			models.PostStatusPublished, models.PostStatusScheduled).Scan(&posts).Error; err != nil {
+			return err
+		}
+
+		return tx.Raw(`UPDATE projects SET status = ?, version = version + 1, updated_at = NOW()
+			WHERE status = ? AND published_at <= NOW() AND deleted_at IS NULL
+			RETURNING id, slug`,
+			models.ProjectStatusPublished, models.ProjectStatusScheduled).Scan(&projects).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		log.Info().Uint("post_id", post.ID).Str("slug", post.Slug).Msg("Published scheduled post")
+	}
+	for _, project := range projects {
+		log.Info().Uint("project_id", project.ID).Str("slug", project.Slug).Msg("Published scheduled project")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JobFunc is a unit of periodic background work
+type JobFunc func(ctx context.Context) error
+
+// job is a registered periodic job
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Runner runs registered jobs on fixed intervals until it is stopped
+type Runner struct {
+	jobs   []job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRunner creates a new job runner
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Add registers a job that runs every interval. Jobs with a non-positive
+// interval are disabled.
+func (r *Runner) Add(name string, interval time.Duration, run JobFunc) {
+	if interval <= 0 {
+		log.Info().Str("job", name).Msg("Background job disabled")
+		return
+	}
+	r.jobs = append(r.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start launches every registered job in its own goroutine
+func (r *Runner) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	for _, j := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(ctx, j)
+	}
+}
+
+// Stop cancels all running jobs and waits for them to return
+func (r *Runner) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	r.wg.Wait()
+}
+
+// loop runs a job immediately and then on every tick
+func (r *Runner) loop(ctx context.Context, j job) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	log.Info().Str("job", j.name).Dur("interval", j.interval).Msg("Background job started")
+	for {
+		if err := j.run(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Str("job", j.name).Msg("Background job failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info().Str("job", j.name).Msg("Background job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
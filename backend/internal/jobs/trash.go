@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"codewithdell/backend/internal/trash"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// TrashPurger permanently deletes content that has been in the trash for
+// longer than the retention period
+type TrashPurger struct {
+	db        *gorm.DB
+	retention time.Duration
+}
+
+// NewTrashPurger creates a new trash purger
+func NewTrashPurger(db *gorm.DB, retention time.Duration) *TrashPurger {
+	return &TrashPurger{db: db, retention: retention}
+}
+
+// Run purges every type of expired trash in one transaction. A non-positive
+// retention keeps the trash forever.
+func (p *TrashPurger) Run(ctx context.Context) error {
+	if p.retention <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-p.retention)
+	purged := make([]int64, len(trash.Types))
+
+	_, err := withAdvisoryLock(ctx, p.db, "jobs:purge-trash", func(tx *gorm.DB) error {
+		for i, t := range trash.Types {
+			count, err := t.PurgeDeletedBefore(tx, cutoff)
+			if err != nil {
+				return err
+			}
+			purged[i] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, t := range trash.Types {
+		if purged[i] > 0 {
+			log.Info().Str("type", t.Name).Int64("count", purged[i]).Msg("Purged expired trash")
+		}
+	}
+
+	return nil
+}
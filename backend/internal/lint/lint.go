@@ -0,0 +1,346 @@
+package lint
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"codewithdell/backend/internal/markdown"
+	"codewithdell/backend/internal/validators"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// Severity tells whether an issue blocks publishing
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rules reported by the linter
+const (
+	RuleContentLength     = "content-length"
+	RuleBrokenLink        = "broken-link"
+	RuleMissingUpload     = "missing-upload"
+	RuleMissingAlt        = "missing-alt"
+	RuleHeadingJump       = "heading-jump"
+	RuleTitleLength       = "title-length"
+	RuleExcerptLength     = "excerpt-length"
+	RuleMissingTags       = "missing-tags"
+	RuleMissingCategories = "missing-categories"
+)
+
+const (
+	// MaxTitleLength is the longest title in characters that search results show in full
+	MaxTitleLength = 70
+	// MaxExcerptLength is the longest excerpt in characters, the length of generated excerpts
+	MaxExcerptLength = markdown.ExcerptLength
+)
+
+// Kinds of content an internal link can point at
+const (
+	KindPost     = "post"
+	KindProject  = "project"
+	KindCategory = "category"
+	KindTag      = "tag"
+)
+
+// Issue is a problem found in a post
+type Issue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Target is the link, image or heading the issue is about
+	Target string `json:"target,omitempty"`
+}
+
+// Report lists the issues found in a post, errors first
+type Report struct {
+	Issues   []Issue `json:"issues"`
+	Errors   int     `json:"errors"`
+	Warnings int     `json:"warnings"`
+}
+
+// HasErrors reports whether the report has issues that block publishing
+func (r *Report) HasErrors() bool {
+	return r.Errors > 0
+}
+
+func (r *Report) add(rule string, severity Severity, target, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Rule: rule, Severity: severity, Message: fmt.Sprintf(format, args...), Target: target})
+	if severity == SeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// Post is the content of a post to lint
+type Post struct {
+	Title      string
+	Excerpt    string
+	Content    string
+	Tags       int
+	Categories int
+}
+
+// Site looks up the content and uploads a post refers to
+type Site interface {
+	// MissingSlugs returns the slugs that match no content of a kind
+	MissingSlugs(kind string, slugs []string) ([]string, error)
+	// UploadExists reports whether a path under /uploads is a stored file
+	UploadExists(path string) bool
+}
+
+// linkPrefixes maps the site paths of content pages to the kind of content
+// they show. Longer prefixes come first.
+var linkPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{"/blog/category/", KindCategory},
+	{"/blog/tag/", KindTag},
+	{"/blog/", KindPost},
+	{"/posts/", KindPost},
+	{"/projects/", KindProject},
+}
+
+var parser = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+var (
+	htmlTagPattern  = regexp.MustCompile(`(?i)<(img|a)\b[^>]*>`)
+	htmlAttrPattern = regexp.MustCompile(`(?i)\b(src|href|alt)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// image is an image in the content with its alternative text
+type image struct {
+	src string
+	alt string
+}
+
+// document holds what the rules look at in the Markdown of a post
+type document struct {
+	links    []string
+	images   []image
+	headings []*ast.Heading
+	source   []byte
+}
+
+// Check lints a post and returns its report. It returns an error when
+// looking up linked content fails.
+func Check(post Post, site Site) (*Report, error) {
+	report := &Report{Issues: []Issue{}}
+
+	if !validators.ValidContent(post.Content) {
+		report.add(RuleContentLength, SeverityError, "",
+			"Content must be %d-%d characters long", validators.MinContentLength, validators.MaxContentLength)
+	}
+
+	doc := parse(post.Content)
+	if err := checkLinks(report, doc, site); err != nil {
+		return nil, err
+	}
+	checkImages(report, doc)
+	checkHeadings(report, doc)
+
+	if length := utf8.RuneCountInString(post.Title); length > MaxTitleLength {
+		report.add(RuleTitleLength, SeverityWarning, "",
+			"Title is %d characters long, keep it within %d", length, MaxTitleLength)
+	}
+	if length := utf8.RuneCountInString(post.Excerpt); length > MaxExcerptLength {
+		report.add(RuleExcerptLength, SeverityWarning, "",
+			"Excerpt is %d characters long, keep it within %d", length, MaxExcerptLength)
+	}
+	if post.Tags == 0 {
+		report.add(RuleMissingTags, SeverityWarning, "", "Post has no tags")
+	}
+	if post.Categories == 0 {
+		report.add(RuleMissingCategories, SeverityWarning, "", "Post has no categories")
+	}
+
+	sortIssues(report.Issues)
+	return report, nil
+}
+
+// parse collects the links, images and headings of Markdown source,
+// including links and images written as raw HTML
+func parse(source string) *document {
+	src := []byte(source)
+	doc := &document{source: src}
+
+	ast.Walk(parser.Parser().Parse(text.NewReader(src)), func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.CodeSpan:
+			return ast.WalkSkipChildren, nil
+		case *ast.Heading:
+			doc.headings = append(doc.headings, node)
+		case *ast.Link:
+			doc.links = append(doc.links, string(node.Destination))
+		case *ast.AutoLink:
+			doc.links = append(doc.links, string(node.URL(src)))
+		case *ast.Image:
+			doc.images = append(doc.images, image{src: string(node.Destination), alt: string(node.Text(src))})
+			return ast.WalkSkipChildren, nil
+		case *ast.RawHTML:
+			var raw strings.Builder
+			for i := 0; i < node.Segments.Len(); i++ {
+				segment := node.Segments.At(i)
+				raw.Write(segment.Value(src))
+			}
+			doc.addHTML(raw.String())
+		case *ast.HTMLBlock:
+			var raw strings.Builder
+			lines := node.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				raw.Write(segment.Value(src))
+			}
+			doc.addHTML(raw.String())
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return doc
+}
+
+// addHTML collects the links and images of raw HTML
+func (d *document) addHTML(html string) {
+	for _, tag := range htmlTagPattern.FindAllStringSubmatch(html, -1) {
+		attrs := map[string]string{}
+		for _, attr := range htmlAttrPattern.FindAllStringSubmatch(tag[0], -1) {
+			attrs[strings.ToLower(attr[1])] = attr[2] + attr[3] + attr[4]
+		}
+		if strings.EqualFold(tag[1], "img") {
+			d.images = append(d.images, image{src: attrs["src"], alt: attrs["alt"]})
+		} else if href, ok := attrs["href"]; ok {
+			d.links = append(d.links, href)
+		}
+	}
+}
+
+// checkLinks reports links to content that does not exist and links and
+// images that refer to uploads that are gone
+func checkLinks(report *Report, doc *document, site Site) error {
+	slugs := map[string][]string{}
+	targets := map[string]map[string]string{}
+	seenUploads := map[string]bool{}
+
+	references := append([]string{}, doc.links...)
+	for _, img := range doc.images {
+		references = append(references, img.src)
+	}
+
+	for _, reference := range references {
+		sitePath, ok := localPath(reference)
+		if !ok {
+			continue
+		}
+
+		if strings.HasPrefix(sitePath, "/uploads/") {
+			if !seenUploads[sitePath] && !site.UploadExists(sitePath) {
+				report.add(RuleMissingUpload, SeverityError, reference, "Upload %s does not exist", sitePath)
+			}
+			seenUploads[sitePath] = true
+			continue
+		}
+
+		kind, slug, ok := contentSlug(sitePath)
+		if !ok {
+			continue
+		}
+		// Each broken slug is reported once, for its first link
+		if targets[kind] == nil {
+			targets[kind] = map[string]string{}
+		}
+		if _, seen := targets[kind][slug]; !seen {
+			targets[kind][slug] = reference
+			slugs[kind] = append(slugs[kind], slug)
+		}
+	}
+
+	for _, kind := range []string{KindPost, KindProject, KindCategory, KindTag} {
+		if len(slugs[kind]) == 0 {
+			continue
+		}
+		missing, err := site.MissingSlugs(kind, slugs[kind])
+		if err != nil {
+			return err
+		}
+		for _, slug := range missing {
+			report.add(RuleBrokenLink, SeverityError, targets[kind][slug], "Link to %s %q that does not exist", kind, slug)
+		}
+	}
+	return nil
+}
+
+// localPath returns the cleaned path of a reference to this site. Links to
+// other hosts, other schemes and anchors on the same page are skipped.
+func localPath(reference string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(reference))
+	if err != nil || u.Scheme != "" || u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+		return "", false
+	}
+	return path.Clean(u.Path), true
+}
+
+// contentSlug returns the kind and slug of content a site path shows
+func contentSlug(sitePath string) (string, string, bool) {
+	for _, prefix := range linkPrefixes {
+		if !strings.HasPrefix(sitePath, prefix.prefix) {
+			continue
+		}
+		slug := strings.TrimPrefix(sitePath, prefix.prefix)
+		if slug == "" || strings.Contains(slug, "/") {
+			return "", "", false
+		}
+		return prefix.kind, slug, true
+	}
+	return "", "", false
+}
+
+// checkImages reports images without alternative text
+func checkImages(report *Report, doc *document) {
+	for _, img := range doc.images {
+		if strings.TrimSpace(img.alt) == "" {
+			report.add(RuleMissingAlt, SeverityWarning, img.src, "Image %s has no alt text", img.src)
+		}
+	}
+}
+
+// checkHeadings reports headings more than one level below the previous
+// heading. The post title counts as the first level one heading.
+func checkHeadings(report *Report, doc *document) {
+	previous := 1
+	for _, heading := range doc.headings {
+		if heading.Level > previous+1 {
+			title := string(heading.Text(doc.source))
+			report.add(RuleHeadingJump, SeverityWarning, title,
+				"Heading %q jumps from h%d to h%d", title, previous, heading.Level)
+		}
+		previous = heading.Level
+	}
+}
+
+// sortIssues moves errors before warnings, keeping the order within each
+func sortIssues(issues []Issue) {
+	sorted := make([]Issue, 0, len(issues))
+	for _, severity := range []Severity{SeverityError, SeverityWarning} {
+		for _, issue := range issues {
+			if issue.Severity == severity {
+				sorted = append(sorted, issue)
+			}
+		}
+	}
+	copy(issues, sorted)
+}
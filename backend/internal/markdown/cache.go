@@ -0,0 +1,84 @@
+package markdown
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// cachedDocument is a rendered document stored with the hash of its source
+type cachedDocument struct {
+	Hash     string    `json:"hash"`
+	Document *Document `json:"document"`
+}
+
+// Cache stores rendered documents in Redis, keyed by owner
+type Cache struct {
+	renderer *Renderer
+	client   *redis.Client
+	prefix   string
+	ttl      time.Duration
+}
+
+// NewCache creates a cache for rendered documents. A nil client disables
+// caching and every call renders the source.
+func NewCache(renderer *Renderer, client *redis.Client, prefix string, ttl time.Duration) *Cache {
+	return &Cache{
+		renderer: renderer,
+		client:   client,
+		prefix:   prefix,
+		ttl:      ttl,
+	}
+}
+
+// Render returns the rendered document for an owner's source, rendering and
+// storing it when the cache is empty or holds an older source
+func (c *Cache) Render(ctx context.Context, id uint, source string) (*Document, error) {
+	hash := sourceHash(source)
+	key := c.key(id)
+
+	if c.client != nil {
+		if data, err := c.client.Get(ctx, key).Bytes(); err == nil {
+			var cached cachedDocument
+			if json.Unmarshal(data, &cached) == nil && cached.Hash == hash && cached.Document != nil {
+				return cached.Document, nil
+			}
+		}
+	}
+
+	doc, err := c.renderer.Render(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.client != nil {
+		// A failed write only costs a render on the next request
+		if data, err := json.Marshal(cachedDocument{Hash: hash, Document: doc}); err == nil {
+			c.client.Set(ctx, key, data, c.ttl)
+		}
+	}
+
+	return doc, nil
+}
+
+// Invalidate drops the cached document of an owner
+func (c *Cache) Invalidate(ctx context.Context, id uint) error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Del(ctx, c.key(id)).Err()
+}
+
+func (c *Cache) key(id uint) string {
+	return fmt.Sprintf("%s:%d", c.prefix, id)
+}
+
+func sourceHash(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// TOCEntry is a heading in a rendered document's table of contents
+type TOCEntry struct {
+	Level    int        `json:"level"`
+	ID       string     `json:"id"`
+	Text     string     `json:"text"`
+	Children []TOCEntry `json:"children,omitempty"`
+}
+
+// Document is the rendered form of a Markdown source
+type Document struct {
+	HTML string     `json:"html"`
+	TOC  []TOCEntry `json:"toc"`
+}
+
+// Renderer turns Markdown into sanitized HTML
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+// NewRenderer creates a renderer with GitHub flavored Markdown, heading
+// anchors and class based syntax highlighting
+func NewRenderer() *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle("github"),
+				highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+			),
+		),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		// Raw HTML in the source is kept and left to the sanitizer
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\w-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^[\w -]+$`)).OnElements("pre", "code", "span")
+
+	return &Renderer{markdown: md, policy: policy}
+}
+
+// Render converts Markdown source into sanitized HTML and a table of contents
+func (r *Renderer) Render(source string) (*Document, error) {
+	src := []byte(source)
+	reader := text.NewReader(src)
+	doc := r.markdown.Parser().Parse(reader)
+
+	var buf bytes.Buffer
+	if err := r.markdown.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return &Document{
+		HTML: r.policy.Sanitize(buf.String()),
+		TOC:  buildTOC(doc, src),
+	}, nil
+}
+
+// buildTOC nests the document's headings by level
+func buildTOC(doc ast.Node, source []byte) []TOCEntry {
+	var headings []TOCEntry
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		id, _ := heading.AttributeString("id")
+		idBytes, _ := id.([]byte)
+		headings = append(headings, TOCEntry{
+			Level: heading.Level,
+			ID:    string(idBytes),
+			Text:  string(heading.Text(source)),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+
+	toc, _ := nestTOC(headings, 0, 0)
+	return toc
+}
+
+// nestTOC groups the headings following a heading under it until one at the
+// same or a shallower level, returning the entries deeper than parentLevel
+// and how many headings were consumed
+func nestTOC(headings []TOCEntry, start, parentLevel int) ([]TOCEntry, int) {
+	var entries []TOCEntry
+	i := start
+	for i < len(headings) && headings[i].Level > parentLevel {
+		entry := headings[i]
+		i++
+		if i < len(headings) && headings[i].Level > entry.Level {
+			children, consumed := nestTOC(headings, i, entry.Level)
+			entry.Children = children
+			i += consumed
+		}
+		entries = append(entries, entry)
+	}
+	return entries, i - start
+}
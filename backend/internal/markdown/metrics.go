@@ -0,0 +1,113 @@
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	// WordsPerMinute is the reading speed used for reading time estimates
+	WordsPerMinute = 200
+	// ExcerptLength is the maximum length in characters of a generated excerpt
+	ExcerptLength = 160
+)
+
+// Summary holds reading metrics and an excerpt computed from Markdown source
+type Summary struct {
+	WordCount   int
+	ReadingTime int
+	Excerpt     string
+}
+
+var analyzer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// Analyze counts the words of the prose in a Markdown source and derives a
+// reading time in minutes and an excerpt from its first paragraphs. Code
+// blocks, raw HTML, images and Markdown syntax are not counted. Any
+// non-empty source takes at least a minute to read.
+func Analyze(source string) Summary {
+	src := []byte(source)
+	doc := analyzer.Parser().Parse(text.NewReader(src))
+
+	var prose strings.Builder
+	var paragraphs []string
+	var paragraph *strings.Builder
+
+	write := func(value []byte) {
+		prose.Write(value)
+		if paragraph != nil {
+			paragraph.Write(value)
+		}
+	}
+
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML, *ast.Image:
+			return ast.WalkSkipChildren, nil
+		case *ast.Paragraph:
+			if entering {
+				paragraph = &strings.Builder{}
+			} else {
+				paragraphs = append(paragraphs, paragraph.String())
+				paragraph = nil
+			}
+		case *ast.Text:
+			if entering {
+				write(node.Segment.Value(src))
+				if node.SoftLineBreak() || node.HardLineBreak() {
+					write([]byte(" "))
+				}
+			}
+		case *ast.String:
+			if entering {
+				write(node.Value)
+			}
+		}
+
+		// Keep words in neighbouring blocks apart
+		if !entering && n.Type() == ast.TypeBlock {
+			prose.WriteByte(' ')
+		}
+		return ast.WalkContinue, nil
+	})
+
+	words := len(strings.Fields(prose.String()))
+	readingTime := (words + WordsPerMinute - 1) / WordsPerMinute
+	// Content with no prose, such as only code or images, still takes a minute
+	if readingTime == 0 && strings.TrimSpace(source) != "" {
+		readingTime = 1
+	}
+
+	return Summary{
+		WordCount:   words,
+		ReadingTime: readingTime,
+		Excerpt:     excerpt(paragraphs, ExcerptLength),
+	}
+}
+
+// excerpt joins paragraphs and cuts them at a word boundary within maxLength characters
+func excerpt(paragraphs []string, maxLength int) string {
+	words := strings.Fields(strings.Join(paragraphs, " "))
+	full := strings.Join(words, " ")
+	if utf8.RuneCountInString(full) <= maxLength {
+		return full
+	}
+
+	var b strings.Builder
+	for _, word := range words {
+		length := utf8.RuneCountInString(b.String())
+		if length > 0 && length+1+utf8.RuneCountInString(word) > maxLength-3 {
+			return b.String() + "..."
+		}
+		if length > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
@@ -55,6 +55,35 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuth middleware sets user info when a valid JWT token is present,
+// but lets anonymous requests through
+func OptionalAuth(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			c.Next()
+			return
+		}
+
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return []byte(jwtSecret), nil
+		})
+		if err != nil || !token.Valid {
+			c.Next()
+			return
+		}
+
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			c.Set("user_id", claims["user_id"])
+			c.Set("email", claims["email"])
+			c.Set("role", claims["role"])
+		}
+
+		c.Next()
+	}
+}
+
 // RequireRole middleware checks if user has required role
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -19,6 +19,8 @@ func CORS(origin string) gin.HandlerFunc {
 		"X-Requested-With",
 		"X-CSRF-Token",
 		"X-API-Key",
+		"If-Match",
+		"If-None-Match",
 	}
 	config.AllowCredentials = true
 	config.ExposeHeaders = []string{
@@ -28,6 +30,7 @@ func CORS(origin string) gin.HandlerFunc {
 		"X-Page-Count",
 		"X-Current-Page",
 		"X-Per-Page",
+		"ETag",
 	}
 	
 	return cors.New(config)
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"codewithdell/backend/internal/config"
+	"codewithdell/backend/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale negotiates the content locale from the lang query parameter or the
+// Accept-Language header and stores it in the context
+func Locale(cfg config.I18nConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"), cfg.Locales, cfg.DefaultLocale)
+
+		c.Set("locale", locale)
+		c.Set("default_locale", cfg.DefaultLocale)
+		c.Set("locales", cfg.Locales)
+
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
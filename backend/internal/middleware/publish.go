@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"codewithdell/backend/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Publish stores the publishing policy in the context
+func Publish(cfg config.PublishConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("lint_blocks_publish", cfg.BlockOnLintErrors)
+		c.Next()
+	}
+}
@@ -143,7 +143,7 @@ func PerEndpointRateLimit(redisClient *redis.Client, configs map[string]RateLimi
 func UserRateLimit(redisClient *redis.Client, config RateLimitConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Only apply rate limiting to authenticated users
-		if userID, exists := c.Get("user_id"); exists {
+		if _, exists := c.Get("user_id"); exists {
 			limiter := NewRateLimiter(redisClient, config)
 			limiter.Handle(c)
 		} else {
@@ -163,7 +163,6 @@ func BurstRateLimit(redisClient *redis.Client, config RateLimitConfig, burstSize
 	return func(c *gin.Context) {
 		clientID := "ip:" + c.ClientIP()
 		key := "burst_rate_limit:" + clientID
-		now := time.Now().Unix()
 
 		// Check current burst count
 		burstCount, err := redisClient.Get(c.Request.Context(), key).Int()
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// ArchiveRule archives published posts once they are older than a number
+// of months, optionally only posts in one category
+type ArchiveRule struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Name         string    `json:"name" gorm:"not null"`
+	CategoryID   *uint     `json:"category_id" gorm:"index"`
+	MaxAgeMonths int       `json:"max_age_months" gorm:"not null"`
+	Enabled      bool      `json:"enabled" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Relationships
+	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+}
+
+// TableName specifies the table name for ArchiveRule
+func (ArchiveRule) TableName() string {
+	return "archive_rules"
+}
+
+// Cutoff returns the publish time before which posts match the rule
+func (r *ArchiveRule) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, -r.MaxAgeMonths, 0)
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditEntry records a change made to content, by an admin or by a
+// background job when ActorID is nil
+type AuditEntry struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;index:idx_audit_entries_entity"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;index:idx_audit_entries_entity"`
+	Action     string    `json:"action" gorm:"not null;index"`
+	Reason     string    `json:"reason"`
+	ActorID    *uint     `json:"actor_id"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// Audit actions
+const (
+	AuditActionArchive = "archive"
+)
+
+// TableName specifies the table name for AuditEntry
+func (AuditEntry) TableName() string {
+	return "audit_entries"
+}
@@ -0,0 +1,66 @@
+package models
+
+// BuildCategoryTree nests a flat list of categories under their parents,
+// keeping the order of the list. Categories whose parent is not in the list
+// are returned as roots.
+func BuildCategoryTree(categories []Category) []Category {
+	present := make(map[uint]bool, len(categories))
+	children := make(map[uint][]Category, len(categories))
+	for _, category := range categories {
+		present[category.ID] = true
+	}
+
+	var roots []Category
+	for _, category := range categories {
+		if category.ParentID != nil && present[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var attach func(nodes []Category, visited map[uint]bool) []Category
+	attach = func(nodes []Category, visited map[uint]bool) []Category {
+		result := make([]Category, 0, len(nodes))
+		for _, node := range nodes {
+			// Guard against cycles left by manual edits
+			if visited[node.ID] {
+				continue
+			}
+			visited[node.ID] = true
+			node.Children = attach(children[node.ID], visited)
+			result = append(result, node)
+		}
+		return result
+	}
+
+	return attach(roots, make(map[uint]bool, len(categories)))
+}
+
+// CategoryDescendantIDs returns the ids of the descendants of a category,
+// nearest first, from a flat list of categories
+func CategoryDescendantIDs(categories []Category, rootID uint) []uint {
+	children := make(map[uint][]uint, len(categories))
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	var ids []uint
+	visited := map[uint]bool{rootID: true}
+	queue := []uint{rootID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			ids = append(ids, child)
+			queue = append(queue, child)
+		}
+	}
+	return ids
+}
@@ -16,13 +16,21 @@ type Category struct {
 	Description string         `json:"description"`
 	Color       string         `json:"color"`
 	Icon        string         `json:"icon"`
+	ParentID    *uint          `json:"parent_id" gorm:"index"`
+	Version     int            `json:"version" gorm:"not null;default:1"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	Posts    []Post    `json:"posts,omitempty" gorm:"many2many:post_categories;"`
-	Projects []Project `json:"projects,omitempty" gorm:"many2many:project_categories;"`
+	Parent   *Category  `json:"parent,omitempty" gorm:"foreignKey:ParentID"`
+	Children []Category `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+	Posts    []Post     `json:"posts,omitempty" gorm:"many2many:post_categories;"`
+	Projects []Project  `json:"projects,omitempty" gorm:"many2many:project_categories;"`
+
+	// Localization, filled in per request
+	Locale     string      `json:"locale,omitempty" gorm:"-"`
+	Alternates []Alternate `json:"alternates,omitempty" gorm:"-"`
 }
 
 // Tag represents a tag for posts and projects
@@ -32,6 +40,7 @@ type Tag struct {
 	Name      string         `json:"name" gorm:"uniqueIndex;not null"`
 	Slug      string         `json:"slug" gorm:"uniqueIndex;not null"`
 	Color     string         `json:"color"`
+	Version   int            `json:"version" gorm:"not null;default:1"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -39,6 +48,10 @@ type Tag struct {
 	// Relationships
 	Posts    []Post    `json:"posts,omitempty" gorm:"many2many:post_tags;"`
 	Projects []Project `json:"projects,omitempty" gorm:"many2many:project_tags;"`
+
+	// Localization, filled in per request
+	Locale     string      `json:"locale,omitempty" gorm:"-"`
+	Alternates []Alternate `json:"alternates,omitempty" gorm:"-"`
 }
 
 // Technology represents a technology used in projects
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Contributor credits a user on a post or project. Contributors are listed
+// by position; the post or project author is one of them.
+type Contributor struct {
+	ID        uint            `json:"id" gorm:"primaryKey"`
+	UserID    uint            `json:"user_id" gorm:"not null;uniqueIndex:idx_contributors_post_user;uniqueIndex:idx_contributors_project_user"`
+	PostID    *uint           `json:"post_id,omitempty" gorm:"uniqueIndex:idx_contributors_post_user"`
+	ProjectID *uint           `json:"project_id,omitempty" gorm:"uniqueIndex:idx_contributors_project_user"`
+	Role      ContributorRole `json:"role" gorm:"not null;default:'author'"`
+	Position  int             `json:"position" gorm:"default:0"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// Relationships
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// ContributorRole represents what a contributor did
+type ContributorRole string
+
+const (
+	ContributorRoleAuthor      ContributorRole = "author"
+	ContributorRoleEditor      ContributorRole = "editor"
+	ContributorRoleIllustrator ContributorRole = "illustrator"
+	ContributorRoleReviewer    ContributorRole = "reviewer"
+)
+
+// TableName specifies the table name for Contributor
+func (Contributor) TableName() string {
+	return "contributors"
+}
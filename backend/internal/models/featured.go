@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// FeaturedType is the kind of content a featured slot shows
+type FeaturedType string
+
+const (
+	FeaturedTypePost    FeaturedType = "post"
+	FeaturedTypeProject FeaturedType = "project"
+)
+
+// FeaturedSlot places a post or project on the homepage featured rail.
+// Slots are shown by ascending position while inside their optional
+// start and end dates.
+type FeaturedSlot struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	Type      FeaturedType `json:"type" gorm:"not null"`
+	PostID    *uint        `json:"post_id" gorm:"index"`
+	ProjectID *uint        `json:"project_id" gorm:"index"`
+	Position  int          `json:"position" gorm:"not null;default:0;index"`
+	StartsAt  *time.Time   `json:"starts_at"`
+	EndsAt    *time.Time   `json:"ends_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	// Relationships
+	Post    *Post    `json:"post,omitempty" gorm:"foreignKey:PostID"`
+	Project *Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+}
+
+// TableName specifies the table name for FeaturedSlot
+func (FeaturedSlot) TableName() string {
+	return "featured_slots"
+}
@@ -18,10 +18,16 @@ type Post struct {
 	FeaturedImage string       `json:"featured_image"`
 	Status      PostStatus     `json:"status" gorm:"default:'draft'"`
 	PublishedAt *time.Time     `json:"published_at"`
+	ExpiresAt   *time.Time     `json:"expires_at" gorm:"index"`
 	AuthorID    uint           `json:"author_id" gorm:"not null"`
 	ViewCount   int            `json:"view_count" gorm:"default:0"`
 	LikeCount   int            `json:"like_count" gorm:"default:0"`
 	CommentCount int           `json:"comment_count" gorm:"default:0"`
+	WordCount   int            `json:"word_count" gorm:"default:0"`
+	ReadingTime int            `json:"reading_time" gorm:"default:0"` // minutes
+	SeriesID    *uint          `json:"series_id" gorm:"index"`
+	SeriesPosition int         `json:"series_position" gorm:"default:0"`
+	Version     int            `json:"version" gorm:"not null;default:1"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
@@ -33,6 +39,11 @@ type Post struct {
 	Comments  []Comment  `json:"comments,omitempty" gorm:"foreignKey:PostID"`
 	Likes     []Like     `json:"likes,omitempty" gorm:"foreignKey:PostID"`
 	Bookmarks []Bookmark `json:"bookmarks,omitempty" gorm:"foreignKey:PostID"`
+	Contributors []Contributor `json:"contributors,omitempty" gorm:"foreignKey:PostID"`
+
+	// Localization, filled in per request
+	Locale     string      `json:"locale,omitempty" gorm:"-"`
+	Alternates []Alternate `json:"alternates,omitempty" gorm:"-"`
 }
 
 // PostStatus represents post status
@@ -42,6 +53,7 @@ const (
 	PostStatusDraft     PostStatus = "draft"
 	PostStatusPublished PostStatus = "published"
 	PostStatusArchived  PostStatus = "archived"
+	PostStatusScheduled PostStatus = "scheduled"
 )
 
 // TableName specifies the table name for Post
@@ -62,6 +74,16 @@ func (p *Post) IsPublished() bool {
 	return p.Status == PostStatusPublished && p.PublishedAt != nil
 }
 
+// IsScheduled checks if the post is waiting for its publish time
+func (p *Post) IsScheduled() bool {
+	return p.Status == PostStatusScheduled && p.PublishedAt != nil
+}
+
+// IsExpired checks if the post has an expiry time that has passed
+func (p *Post) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !p.ExpiresAt.After(now)
+}
+
 // IncrementViewCount increments the view count
 func (p *Post) IncrementViewCount() {
 	p.ViewCount++
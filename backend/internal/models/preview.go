@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"codewithdell/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// PreviewToken grants read access to a single unpublished post or project.
+// Only a hash of the token is stored.
+type PreviewToken struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UUID        string     `json:"uuid" gorm:"uniqueIndex;not null"`
+	ContentType string     `json:"content_type" gorm:"not null;index:idx_preview_tokens_content"`
+	ContentID   uint       `json:"content_id" gorm:"not null;index:idx_preview_tokens_content"`
+	TokenHash   string     `json:"-" gorm:"uniqueIndex;not null"`
+	Note        string     `json:"note"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	ViewCount   int        `json:"view_count" gorm:"default:0"`
+	CreatedByID uint       `json:"created_by_id" gorm:"not null"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// Relationships
+	CreatedBy User `json:"created_by" gorm:"foreignKey:CreatedByID"`
+}
+
+// TableName specifies the table name for PreviewToken
+func (PreviewToken) TableName() string {
+	return "preview_tokens"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a preview token
+func (t *PreviewToken) BeforeCreate(tx *gorm.DB) error {
+	if t.UUID == "" {
+		t.UUID = utils.GenerateUUID()
+	}
+	return nil
+}
+
+// IsActive checks if the token is neither revoked nor expired
+func (t *PreviewToken) IsActive() bool {
+	return t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
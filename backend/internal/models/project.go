@@ -22,6 +22,7 @@ type Project struct {
 	ViewCount   int            `json:"view_count" gorm:"default:0"`
 	LikeCount   int            `json:"like_count" gorm:"default:0"`
 	CommentCount int           `json:"comment_count" gorm:"default:0"`
+	Version     int            `json:"version" gorm:"not null;default:1"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
@@ -43,6 +44,11 @@ type Project struct {
 	Likes      []Like      `json:"likes,omitempty" gorm:"foreignKey:ProjectID"`
 	Bookmarks  []Bookmark  `json:"bookmarks,omitempty" gorm:"foreignKey:ProjectID"`
 	Screenshots []Screenshot `json:"screenshots,omitempty" gorm:"foreignKey:ProjectID"`
+	Contributors []Contributor `json:"contributors,omitempty" gorm:"foreignKey:ProjectID"`
+
+	// Localization, filled in per request
+	Locale     string      `json:"locale,omitempty" gorm:"-"`
+	Alternates []Alternate `json:"alternates,omitempty" gorm:"-"`
 }
 
 // ProjectStatus represents project status
@@ -52,6 +58,7 @@ const (
 	ProjectStatusDraft     ProjectStatus = "draft"
 	ProjectStatusPublished ProjectStatus = "published"
 	ProjectStatusArchived  ProjectStatus = "archived"
+	ProjectStatusScheduled ProjectStatus = "scheduled"
 )
 
 // ProjectDifficulty represents project difficulty level
@@ -82,6 +89,11 @@ func (p *Project) IsPublished() bool {
 	return p.Status == ProjectStatusPublished && p.PublishedAt != nil
 }
 
+// IsScheduled checks if the project is waiting for its publish time
+func (p *Project) IsScheduled() bool {
+	return p.Status == ProjectStatusScheduled && p.PublishedAt != nil
+}
+
 // IncrementViewCount increments the view count
 func (p *Project) IncrementViewCount() {
 	p.ViewCount++
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"codewithdell/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// PostRevision represents a saved version of a post's title, excerpt and content
+type PostRevision struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	UUID         string    `json:"uuid" gorm:"uniqueIndex;not null"`
+	PostID       uint      `json:"post_id" gorm:"not null;uniqueIndex:idx_post_revisions_post_version"`
+	Version      int       `json:"version" gorm:"not null;uniqueIndex:idx_post_revisions_post_version"`
+	Title        string    `json:"title" gorm:"not null"`
+	Excerpt      string    `json:"excerpt"`
+	Content      string    `json:"content,omitempty" gorm:"type:text;not null"`
+	EditorID     uint      `json:"editor_id" gorm:"not null"`
+	RestoredFrom *int      `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relationships
+	Editor User `json:"editor" gorm:"foreignKey:EditorID"`
+}
+
+// TableName specifies the table name for PostRevision
+func (PostRevision) TableName() string {
+	return "post_revisions"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a revision
+func (r *PostRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.UUID == "" {
+		r.UUID = utils.GenerateUUID()
+	}
+	return nil
+}
+
+// Snapshot returns the revision as a single text document for diffing
+func (r *PostRevision) Snapshot() string {
+	return "Title: " + r.Title + "\nExcerpt: " + r.Excerpt + "\n\n" + r.Content
+}
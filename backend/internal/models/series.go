@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"codewithdell/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Series represents an ordered, multi-part collection of posts
+type Series struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	UUID          string         `json:"uuid" gorm:"uniqueIndex;not null"`
+	Title         string         `json:"title" gorm:"not null"`
+	Slug          string         `json:"slug" gorm:"uniqueIndex;not null"`
+	Description   string         `json:"description" gorm:"type:text"`
+	FeaturedImage string         `json:"featured_image"`
+	PostCount     int64          `json:"post_count" gorm:"->;-:migration"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Posts []Post `json:"posts,omitempty" gorm:"foreignKey:SeriesID"`
+}
+
+// TableName specifies the table name for Series
+func (Series) TableName() string {
+	return "series"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a series
+func (s *Series) BeforeCreate(tx *gorm.DB) error {
+	if s.UUID == "" {
+		s.UUID = utils.GenerateUUID()
+	}
+	return nil
+}
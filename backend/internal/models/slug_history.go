@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// SlugHistory records a slug a post, tag or category used before it was
+// renamed, so links to the old slug can be redirected to the current one
+type SlugHistory struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;uniqueIndex:idx_slug_histories_entity_slug"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;index"`
+	Slug       string    `json:"slug" gorm:"not null;uniqueIndex:idx_slug_histories_entity_slug"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for SlugHistory
+func (SlugHistory) TableName() string {
+	return "slug_histories"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Translation holds a post, project, category or tag in a locale other than
+// the default one. Categories and tags use Title for their name and Excerpt
+// for their description; projects use Excerpt for their description.
+type Translation struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;uniqueIndex:idx_translations_entity_locale;uniqueIndex:idx_translations_locale_slug"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;uniqueIndex:idx_translations_entity_locale"`
+	Locale     string    `json:"locale" gorm:"not null;size:16;uniqueIndex:idx_translations_entity_locale;uniqueIndex:idx_translations_locale_slug"`
+	Title      string    `json:"title" gorm:"not null"`
+	Slug       string    `json:"slug" gorm:"not null;uniqueIndex:idx_translations_locale_slug"`
+	Content    string    `json:"content" gorm:"type:text"`
+	Excerpt    string    `json:"excerpt" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Translation
+func (Translation) TableName() string {
+	return "translations"
+}
+
+// Alternate links to a version of a piece of content in another locale
+type Alternate struct {
+	Hreflang string `json:"hreflang"`
+	Slug     string `json:"slug"`
+	Href     string `json:"href"`
+}
@@ -0,0 +1,117 @@
+package related
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Score weights. Shared taxonomy dominates, text similarity separates
+// candidates with the same overlap and recency breaks the remaining ties.
+const (
+	TagWeight      = 3.0
+	CategoryWeight = 2.0
+	TextWeight     = 4.0
+	RecencyWeight  = 1.0
+
+	// recencyHalfLife is the age at which the recency score halves
+	recencyHalfLife = 30 * 24 * time.Hour
+)
+
+// Item is a piece of content considered for recommendation. SharedTags and
+// SharedCategories count what a candidate has in common with the target.
+type Item struct {
+	ID               uint
+	Title            string
+	Excerpt          string
+	SharedTags       int
+	SharedCategories int
+	Date             time.Time
+}
+
+// Rank returns the ids of the best candidates for target, highest score first
+func Rank(target Item, candidates []Item, now time.Time, limit int) []uint {
+	type scored struct {
+		id    uint
+		score float64
+		date  time.Time
+	}
+	results := make([]scored, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID == target.ID {
+			continue
+		}
+		results = append(results, scored{
+			id:    candidate.ID,
+			score: Score(target, candidate, now),
+			date:  candidate.Date,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].date.After(results[j].date)
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	ids := make([]uint, len(results))
+	for i, result := range results {
+		ids[i] = result.id
+	}
+	return ids
+}
+
+// Score rates a candidate by its overlap with target and its age
+func Score(target, candidate Item, now time.Time) float64 {
+	similarity := jaccard(words(target.Title+" "+target.Excerpt), words(candidate.Title+" "+candidate.Excerpt))
+	score := TagWeight*float64(candidate.SharedTags) +
+		CategoryWeight*float64(candidate.SharedCategories) +
+		TextWeight*similarity
+
+	if !candidate.Date.IsZero() {
+		age := now.Sub(candidate.Date)
+		if age < 0 {
+			age = 0
+		}
+		score += RecencyWeight / (1 + float64(age)/float64(recencyHalfLife))
+	}
+	return score
+}
+
+// words returns the set of significant lowercase words in text
+func words(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) > 2 && !stopWords[word] {
+			set[word] = true
+		}
+	}
+	return set
+}
+
+// jaccard returns the size of the intersection of two sets over their union
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "your": true, "you": true, "are": true, "how": true, "what": true,
+	"why": true, "into": true, "using": true, "about": true, "our": true, "its": true,
+}
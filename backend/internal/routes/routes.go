@@ -20,6 +20,7 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 	
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	v1.Use(middleware.Locale(cfg.I18n))
 	{
 		// Public routes
 		public := v1.Group("")
@@ -35,8 +36,33 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 			// Public content routes
 			posts := public.Group("/posts")
 			{
-				posts.GET("", handlers.GetPosts)
+				posts.GET("", middleware.OptionalAuth(cfg.JWT.Secret), handlers.GetPosts)
 				posts.GET("/:slug", handlers.GetPostBySlug)
+				posts.GET("/:slug/related", handlers.GetRelatedPosts)
+			}
+
+			// Series routes
+			series := public.Group("/series")
+			{
+				series.GET("", handlers.GetSeriesList)
+				series.GET("/:slug", handlers.GetSeriesBySlug)
+			}
+
+			// Public project routes
+			projects := public.Group("/projects")
+			{
+				projects.GET("", handlers.GetProjects)
+				projects.GET("/:slug", handlers.GetProjectBySlug)
+				projects.GET("/:slug/related", handlers.GetRelatedProjects)
+			}
+
+			// Technologies routes
+			technologies := public.Group("/technologies")
+			{
+				technologies.GET("", handlers.GetTechnologies)
+				technologies.GET("/radar", handlers.GetTechRadar)
+				technologies.GET("/:slug", handlers.GetTechnologyBySlug)
+				technologies.GET("/:slug/projects", handlers.GetTechnologyProjects)
 			}
 
 			// Categories routes
@@ -67,7 +93,7 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 			// Search routes
 			search := public.Group("/search")
 			{
-				search.GET("", handlers.Search)
+				search.GET("", middleware.OptionalAuth(cfg.JWT.Secret), handlers.Search)
 				search.GET("/suggestions", handlers.GetSearchSuggestions)
 				search.GET("/stats", handlers.GetSearchStats)
 			}
@@ -80,6 +106,12 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 				analytics.GET("/users/:id", handlers.GetUserStats)
 			}
 
+			// Draft previews for holders of a preview link
+			public.GET("/preview/:token", handlers.GetPreview)
+
+			// Featured content rail
+			public.GET("/featured", handlers.GetFeatured)
+
 			// Simple test endpoint
 			public.GET("/test", handlers.TestEndpoint)
 		}
@@ -98,11 +130,11 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 			// User interactions
 			interactions := protected.Group("/interactions")
 			{
-				interactions.POST("/posts/:id/like", handlers.LikePost)
-				interactions.DELETE("/posts/:id/like", handlers.UnlikePost)
-				interactions.POST("/posts/:id/bookmark", handlers.BookmarkPost)
-				interactions.DELETE("/posts/:id/bookmark", handlers.RemoveBookmark)
-				interactions.GET("/posts/:id/check", handlers.CheckUserInteraction)
+				interactions.POST("/:type/:id/like", handlers.LikeContent)
+				interactions.DELETE("/:type/:id/like", handlers.UnlikeContent)
+				interactions.POST("/:type/:id/bookmark", handlers.BookmarkContent)
+				interactions.DELETE("/:type/:id/bookmark", handlers.RemoveBookmark)
+				interactions.GET("/:type/:id/check", handlers.CheckUserInteraction)
 				interactions.GET("/likes", handlers.GetUserLikes)
 				interactions.GET("/bookmarks", handlers.GetUserBookmarks)
 			}
@@ -127,14 +159,64 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 
 		// Admin routes (require admin role)
 		admin := v1.Group("/admin")
-		admin.Use(middleware.Auth(cfg.JWT.Secret), middleware.RequireRole("admin"))
+		admin.Use(middleware.Auth(cfg.JWT.Secret), middleware.RequireRole("admin"), middleware.Publish(cfg.Publish))
 		{
 			// Content management
 			posts := admin.Group("/posts")
 			{
 				posts.POST("", handlers.CreatePost)
+				posts.POST("/import", handlers.ImportPosts)
 				posts.PUT("/:id", handlers.UpdatePost)
 				posts.DELETE("/:id", handlers.DeletePost)
+				posts.GET("/:id/lint", handlers.LintPost)
+				posts.GET("/:id/revisions", handlers.GetPostRevisions)
+				posts.GET("/:id/revisions/diff", handlers.DiffPostRevisions)
+				posts.GET("/:id/revisions/:version", handlers.GetPostRevision)
+				posts.POST("/:id/revisions/:version/restore", handlers.RestorePostRevision)
+			}
+
+			// Series management
+			series := admin.Group("/series")
+			{
+				series.POST("", handlers.CreateSeries)
+				series.PUT("/:id", handlers.UpdateSeries)
+				series.DELETE("/:id", handlers.DeleteSeries)
+				series.POST("/:id/posts", handlers.AddSeriesPosts)
+				series.PUT("/:id/posts/order", handlers.ReorderSeriesPosts)
+				series.DELETE("/:id/posts/:post_id", handlers.RemoveSeriesPost)
+			}
+
+			// Preview links for unpublished content
+			previews := admin.Group("/previews")
+			{
+				previews.POST("/:type/:id", handlers.CreatePreview)
+				previews.GET("/:type/:id", handlers.GetPreviews)
+				previews.DELETE("/:id", handlers.RevokePreview)
+			}
+
+			// Projects management
+			projects := admin.Group("/projects")
+			{
+				projects.POST("", handlers.CreateProject)
+				projects.PUT("/:id", handlers.UpdateProject)
+				projects.DELETE("/:id", handlers.DeleteProject)
+				projects.POST("/:id/screenshots", handlers.AddProjectScreenshots)
+				projects.PUT("/:id/screenshots/order", handlers.ReorderProjectScreenshots)
+			}
+
+			// Technologies management
+			technologies := admin.Group("/technologies")
+			{
+				technologies.POST("", handlers.CreateTechnology)
+				technologies.PUT("/:id", handlers.UpdateTechnology)
+				technologies.DELETE("/:id", handlers.DeleteTechnology)
+			}
+
+			// Screenshot management
+			screenshots := admin.Group("/screenshots")
+			{
+				screenshots.PUT("/:id", handlers.UpdateScreenshot)
+				screenshots.DELETE("/:id", handlers.DeleteScreenshot)
 			}
 
 			// Categories management
@@ -153,6 +235,14 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 				tags.DELETE("/:id", handlers.DeleteTag)
 			}
 
+			// Translations management
+			translations := admin.Group("/translations")
+			{
+				translations.GET("/:type/:id", handlers.GetTranslations)
+				translations.PUT("/:type/:id/:locale", handlers.SaveTranslation)
+				translations.DELETE("/:type/:id/:locale", handlers.DeleteTranslation)
+			}
+
 			// Comments moderation
 			comments := admin.Group("/comments")
 			{
@@ -160,6 +250,41 @@ func Setup(router *gin.Engine, cfg *config.Config) {
 				comments.POST("/:id/approve", handlers.ApproveComment)
 				comments.POST("/:id/reject", handlers.RejectComment)
 			}
+
+			// Trash bin
+			trashBin := admin.Group("/trash")
+			{
+				trashBin.GET("", handlers.GetTrashSummary)
+				trashBin.GET("/:type", handlers.GetTrash)
+				trashBin.POST("/:type/:id/restore", handlers.RestoreTrashItem)
+				trashBin.DELETE("/:type/:id", handlers.PurgeTrashItem)
+			}
+
+			// Featured content rail
+			featured := admin.Group("/featured")
+			{
+				featured.GET("", handlers.GetFeaturedSlots)
+				featured.POST("", handlers.CreateFeaturedSlot)
+				featured.PUT("/order", handlers.ReorderFeaturedSlots)
+				featured.PUT("/:id", handlers.UpdateFeaturedSlot)
+				featured.DELETE("/:id", handlers.DeleteFeaturedSlot)
+			}
+
+			// Archive rules and audit log
+			archiveRules := admin.Group("/archive-rules")
+			{
+				archiveRules.GET("", handlers.GetArchiveRules)
+				archiveRules.POST("", handlers.CreateArchiveRule)
+				archiveRules.PUT("/:id", handlers.UpdateArchiveRule)
+				archiveRules.DELETE("/:id", handlers.DeleteArchiveRule)
+			}
+			admin.GET("/audit", handlers.GetAuditEntries)
+
+			// Bulk content operations
+			admin.POST("/bulk/:type", handlers.BulkContent)
+
+			// Site export
+			admin.GET("/export", handlers.ExportSite)
 		}
 	}
 
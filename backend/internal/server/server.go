@@ -11,6 +11,7 @@ import (
 
 	"codewithdell/backend/internal/config"
 	"codewithdell/backend/internal/database"
+	"codewithdell/backend/internal/jobs"
 	"codewithdell/backend/internal/logger"
 	"codewithdell/backend/internal/middleware"
 	"codewithdell/backend/internal/redis"
@@ -25,6 +26,7 @@ type Server struct {
 	router *gin.Engine
 	config *config.Config
 	server *http.Server
+	jobs   *jobs.Runner
 }
 
 // New creates a new server instance
@@ -58,6 +60,17 @@ func (s *Server) Initialize() error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Register background jobs
+	s.jobs = jobs.NewRunner()
+	s.jobs.Add("publish-scheduled", s.config.Jobs.PublishInterval, jobs.NewPublisher(database.GetDB()).Run)
+	s.jobs.Add("archive-content", s.config.Jobs.ArchiveInterval, jobs.NewArchiver(database.GetDB()).Run)
+	purgeInterval := s.config.Jobs.TrashPurgeInterval
+	if s.config.Jobs.TrashRetention <= 0 {
+		// Trash is kept forever when retention is disabled
+		purgeInterval = 0
+	}
+	s.jobs.Add("purge-trash", purgeInterval, jobs.NewTrashPurger(database.GetDB(), s.config.Jobs.TrashRetention).Run)
+
 	// Add database to context using the global DB instance
 	s.router.Use(func(c *gin.Context) {
 		c.Set("db", database.GetDB())
@@ -65,11 +78,10 @@ func (s *Server) Initialize() error {
 	})
 
 	// Initialize Redis
-	redisClient, err := redis.NewClient(s.config.Redis)
-	if err != nil {
+	// The client stays open for the handlers and is closed on shutdown
+	if _, err := redis.NewClient(s.config.Redis); err != nil {
 		return fmt.Errorf("failed to initialize Redis: %w", err)
 	}
-	defer redisClient.Close()
 
 	// Add middleware
 	s.router.Use(middleware.Logger(logger))
@@ -94,6 +106,9 @@ func (s *Server) Start() error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Start background jobs
+	s.jobs.Start()
+
 	// Start server in a goroutine
 	go func() {
 		log.Info().Msgf("Starting server on port %s", s.config.App.Port)
@@ -109,6 +124,9 @@ func (s *Server) Start() error {
 
 	log.Info().Msg("Shutting down server...")
 
+	// Stop background jobs before closing connections
+	s.jobs.Stop()
+
 	// Create a deadline for server shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -118,6 +136,10 @@ func (s *Server) Start() error {
 		log.Fatal().Err(err).Msg("Server forced to shutdown")
 	}
 
+	if err := redis.Close(redis.GetClient()); err != nil {
+		log.Error().Err(err).Msg("Failed to close Redis client")
+	}
+
 	log.Info().Msg("Server exited")
 	return nil
 } 
\ No newline at end of file
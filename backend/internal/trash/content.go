@@ -0,0 +1,194 @@
+package trash
+
+import (
+	"gorm.io/gorm"
+)
+
+// restoreCategoryChain restores the deleted categories selected by a
+// subquery together with their deleted ancestors, so a restored item never
+// hangs below a deleted category
+func restoreCategoryChain(tx *gorm.DB, selection string, args ...interface{}) (int64, error) {
+	result := tx.Exec(`WITH RECURSIVE chain AS (
+			SELECT id, parent_id FROM categories WHERE id IN (`+selection+`)
+			UNION
+			SELECT categories.id, categories.parent_id FROM categories JOIN chain ON categories.id = chain.parent_id
+		)
+		UPDATE categories SET deleted_at = NULL
+		WHERE deleted_at IS NOT NULL AND id IN (SELECT id FROM chain)`, args...)
+	return result.RowsAffected, result.Error
+}
+
+// restoreLinked restores the deleted rows of table linked to an item
+// through a join table
+func restoreLinked(tx *gorm.DB, table, joinTable, column, owner string, id uint) (int64, error) {
+	result := tx.Exec(`UPDATE `+table+` SET deleted_at = NULL
+		WHERE deleted_at IS NOT NULL AND id IN (SELECT `+column+` FROM `+joinTable+` WHERE `+owner+` = ?)`, id)
+	return result.RowsAffected, result.Error
+}
+
+// restorePost restores the deleted tags and categories of a post
+func restorePost(tx *gorm.DB, id uint) (map[string]int64, error) {
+	tags, err := restoreLinked(tx, "tags", "post_tags", "tag_id", "post_id", id)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := restoreCategoryChain(tx, "SELECT category_id FROM post_categories WHERE post_id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"tags": tags, "categories": categories}, nil
+}
+
+// restoreProject restores the deleted tags, categories and technologies of a project
+func restoreProject(tx *gorm.DB, id uint) (map[string]int64, error) {
+	tags, err := restoreLinked(tx, "tags", "project_tags", "tag_id", "project_id", id)
+	if err != nil {
+		return nil, err
+	}
+	technologies, err := restoreLinked(tx, "technologies", "project_technologies", "technology_id", "project_id", id)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := restoreCategoryChain(tx, "SELECT category_id FROM project_categories WHERE project_id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"tags": tags, "categories": categories, "technologies": technologies}, nil
+}
+
+// restoreCategory restores the deleted ancestors of a category
+func restoreCategory(tx *gorm.DB, id uint) (map[string]int64, error) {
+	categories, err := restoreCategoryChain(tx, "SELECT parent_id FROM categories WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"categories": categories}, nil
+}
+
+// restoreComment restores the deleted comments a comment replies to. The
+// post or project of the comment has to be restored first.
+func restoreComment(tx *gorm.DB, id uint) (map[string]int64, error) {
+	var comment struct {
+		PostID    *uint
+		ProjectID *uint
+	}
+	if err := tx.Table("comments").Select("post_id, project_id").Where("id = ?", id).Scan(&comment).Error; err != nil {
+		return nil, err
+	}
+
+	for _, owner := range []struct {
+		table string
+		label string
+		id    *uint
+	}{
+		{"posts", "post", comment.PostID},
+		{"projects", "project", comment.ProjectID},
+	} {
+		if owner.id == nil {
+			continue
+		}
+		var count int64
+		if err := tx.Table(owner.table).Where("id = ? AND deleted_at IS NULL", *owner.id).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, &ConflictError{Reason: "The " + owner.label + " of this comment is in the trash, restore it first"}
+		}
+	}
+
+	result := tx.Exec(`WITH RECURSIVE chain AS (
+			SELECT id, parent_id FROM comments WHERE id = (SELECT parent_id FROM comments WHERE id = ?)
+			UNION
+			SELECT comments.id, comments.parent_id FROM comments JOIN chain ON comments.id = chain.parent_id
+		)
+		UPDATE comments SET deleted_at = NULL
+		WHERE deleted_at IS NOT NULL AND id IN (SELECT id FROM chain)`, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return map[string]int64{"comments": result.RowsAffected}, nil
+}
+
+// deleteWhere runs a DELETE for every table and condition pair
+func deleteWhere(tx *gorm.DB, statements [][2]string, args ...interface{}) error {
+	for _, statement := range statements {
+		if err := tx.Exec("DELETE FROM "+statement[0]+" WHERE "+statement[1], args...).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgePosts removes the tags, categories, comments, likes, bookmarks,
+// revisions, contributors, featured slots and preview links of posts
+func purgePosts(tx *gorm.DB, ids []uint) error {
+	if err := deleteWhere(tx, [][2]string{
+		{"post_tags", "post_id IN ?"},
+		{"post_categories", "post_id IN ?"},
+		{"comments", "post_id IN ?"},
+		{"likes", "post_id IN ?"},
+		{"bookmarks", "post_id IN ?"},
+		{"post_revisions", "post_id IN ?"},
+		{"contributors", "post_id IN ?"},
+		{"featured_slots", "post_id IN ?"},
+	}, ids); err != nil {
+		return err
+	}
+	return tx.Exec("DELETE FROM preview_tokens WHERE content_type = ? AND content_id IN ?", "post", ids).Error
+}
+
+// purgeProjects removes the tags, categories, technologies, comments, likes,
+// bookmarks, screenshots, contributors, featured slots and preview links
+// of projects
+func purgeProjects(tx *gorm.DB, ids []uint) error {
+	if err := deleteWhere(tx, [][2]string{
+		{"project_tags", "project_id IN ?"},
+		{"project_categories", "project_id IN ?"},
+		{"project_technologies", "project_id IN ?"},
+		{"comments", "project_id IN ?"},
+		{"likes", "project_id IN ?"},
+		{"bookmarks", "project_id IN ?"},
+		{"screenshots", "project_id IN ?"},
+		{"contributors", "project_id IN ?"},
+		{"featured_slots", "project_id IN ?"},
+	}, ids); err != nil {
+		return err
+	}
+	return tx.Exec("DELETE FROM preview_tokens WHERE content_type = ? AND content_id IN ?", "project", ids).Error
+}
+
+// purgeCategories unlinks categories from posts and projects and moves
+// their subcategories up to their parent
+func purgeCategories(tx *gorm.DB, ids []uint) error {
+	if err := deleteWhere(tx, [][2]string{
+		{"post_categories", "category_id IN ?"},
+		{"project_categories", "category_id IN ?"},
+	}, ids); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := tx.Exec("UPDATE categories SET parent_id = (SELECT parent_id FROM categories WHERE id = ?) WHERE parent_id = ?", id, id).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeTags unlinks tags from posts and projects
+func purgeTags(tx *gorm.DB, ids []uint) error {
+	return deleteWhere(tx, [][2]string{
+		{"post_tags", "tag_id IN ?"},
+		{"project_tags", "tag_id IN ?"},
+	}, ids)
+}
+
+// purgeComments moves the replies to comments up to the comment they
+// replied to, so threads stay intact
+func purgeComments(tx *gorm.DB, ids []uint) error {
+	for _, id := range ids {
+		if err := tx.Exec("UPDATE comments SET parent_id = (SELECT parent_id FROM comments WHERE id = ?) WHERE parent_id = ?", id, id).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
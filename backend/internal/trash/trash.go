@@ -0,0 +1,167 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned for items that are not in the trash
+var ErrNotFound = errors.New("item not found in trash")
+
+// ConflictError is returned when an item cannot be restored before
+// something it belongs to is
+type ConflictError struct {
+	Reason string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Reason
+}
+
+// Item is a soft-deleted row as listed in the trash
+type Item struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Type is a kind of content that can be trashed
+type Type struct {
+	// Name is the route name of the type, such as posts
+	Name string
+	// Label is the singular name used in messages
+	Label string
+	// EntityType is the type name used by slug history, translations and previews
+	EntityType string
+	table      string
+	title      string
+	slug       string
+	// restore brings back what a restored row needs, and counts it by kind
+	restore func(tx *gorm.DB, id uint) (map[string]int64, error)
+	// purge removes the rows that refer to the purged rows
+	purge func(tx *gorm.DB, ids []uint) error
+}
+
+// Types lists the content types of the trash in the order they are purged
+var Types = []*Type{
+	{Name: "comments", Label: "Comment", EntityType: "comment", table: "comments", title: "LEFT(content, 100)", restore: restoreComment, purge: purgeComments},
+	{Name: "posts", Label: "Post", EntityType: "post", table: "posts", title: "title", slug: "slug", restore: restorePost, purge: purgePosts},
+	{Name: "projects", Label: "Project", EntityType: "project", table: "projects", title: "title", slug: "slug", restore: restoreProject, purge: purgeProjects},
+	{Name: "categories", Label: "Category", EntityType: "category", table: "categories", title: "name", slug: "slug", restore: restoreCategory, purge: purgeCategories},
+	{Name: "tags", Label: "Tag", EntityType: "tag", table: "tags", title: "name", slug: "slug", purge: purgeTags},
+}
+
+// Lookup returns the type with a route name
+func Lookup(name string) (*Type, bool) {
+	for _, t := range Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Count returns the number of trashed rows of the type
+func (t *Type) Count(db *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Table(t.table).Where("deleted_at IS NOT NULL").Count(&count).Error
+	return count, err
+}
+
+// List returns a page of trashed rows, most recently deleted first
+func (t *Type) List(db *gorm.DB, offset, limit int) ([]Item, int64, error) {
+	total, err := t.Count(db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	slug := "''"
+	if t.slug != "" {
+		slug = t.slug
+	}
+	items := []Item{}
+	err = db.Table(t.table).
+		Select(fmt.Sprintf("id, %s AS title, %s AS slug, deleted_at", t.title, slug)).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Scan(&items).Error
+	return items, total, err
+}
+
+// Restore brings back a trashed row together with the deleted rows it
+// refers to, such as the tags of a post or the parent of a category. It
+// returns how many of those were restored, by kind.
+func (t *Type) Restore(db *gorm.DB, id uint) (map[string]int64, error) {
+	restored := map[string]int64{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := t.trashed(tx, id); err != nil {
+			return err
+		}
+		if t.restore != nil {
+			related, err := t.restore(tx, id)
+			if err != nil {
+				return err
+			}
+			restored = related
+		}
+		return tx.Table(t.table).Where("id = ?", id).Update("deleted_at", nil).Error
+	})
+	return restored, err
+}
+
+// Purge permanently deletes a trashed row and everything that refers to it
+func (t *Type) Purge(db *gorm.DB, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := t.trashed(tx, id); err != nil {
+			return err
+		}
+		return t.purgeRows(tx, []uint{id})
+	})
+}
+
+// PurgeDeletedBefore permanently deletes the rows of the type trashed before
+// cutoff and returns how many there were
+func (t *Type) PurgeDeletedBefore(tx *gorm.DB, cutoff time.Time) (int64, error) {
+	var ids []uint
+	if err := tx.Table(t.table).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := t.purgeRows(tx, ids); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// trashed checks that a row of the type is in the trash
+func (t *Type) trashed(tx *gorm.DB, id uint) error {
+	var count int64
+	if err := tx.Table(t.table).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// purgeRows deletes the rows that refer to ids, then the rows themselves
+func (t *Type) purgeRows(tx *gorm.DB, ids []uint) error {
+	if err := t.purge(tx, ids); err != nil {
+		return err
+	}
+	for _, table := range []string{"slug_histories", "translations"} {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE entity_type = ? AND entity_id IN ?", t.EntityType, ids).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Exec("DELETE FROM "+t.table+" WHERE id IN ?", ids).Error
+}
@@ -161,17 +161,26 @@ func validateURL(fl validator.FieldLevel) bool {
 	return matched
 }
 
+// Content length limits enforced by the content validation
+const (
+	MinContentLength = 10
+	MaxContentLength = 50000
+)
+
 // validateContent validates content length and format
 func validateContent(fl validator.FieldLevel) bool {
-	content := fl.Field().String()
-	
+	return ValidContent(fl.Field().String())
+}
+
+// ValidContent checks that content is 10-50,000 characters long and not only whitespace
+func ValidContent(content string) bool {
 	// Content must be at least 10 characters long
-	if len(content) < 10 {
+	if len(content) < MinContentLength {
 		return false
 	}
 	
 	// Content must not exceed 50,000 characters
-	if len(content) > 50000 {
+	if len(content) > MaxContentLength {
 		return false
 	}
 	
@@ -259,8 +268,8 @@ func ValidateEmail(email string) bool {
 
 // ValidatePhone validates phone number format
 func ValidatePhone(phone string) bool {
-	phoneRegex := regexp.MatchString(`^\+?[1-9]\d{1,14}$`, phone)
-	return phoneRegex
+	matched, _ := regexp.MatchString(`^\+?[1-9]\d{1,14}$`, phone)
+	return matched
 }
 
 // ValidateDate validates date format (YYYY-MM-DD)
@@ -277,6 +286,6 @@ func ValidateDate(date string) bool {
 
 // ValidateTime validates time format (HH:MM:SS)
 func ValidateTime(timeStr string) bool {
-	timeRegex := regexp.MatchString(`^([01]?[0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`, timeStr)
-	return timeRegex
+	matched, _ := regexp.MatchString(`^([01]?[0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`, timeStr)
+	return matched
 } 
\ No newline at end of file
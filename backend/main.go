@@ -1,6 +1,9 @@
 package main
 
 import (
+	"os"
+
+	"codewithdell/backend/internal/commands"
 	"codewithdell/backend/internal/config"
 	"codewithdell/backend/internal/server"
 
@@ -39,6 +42,14 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to load configuration")
 	}
 
+	// Run a maintenance command instead of the server when one is given
+	if len(os.Args) > 1 {
+		if err := commands.Run(cfg, os.Args[1:]); err != nil {
+			log.Fatal().Err(err).Msg("Command failed")
+		}
+		return
+	}
+
 	// Create and initialize server
 	srv := server.New(cfg)
 	if err := srv.Initialize(); err != nil {
@@ -0,0 +1,56 @@
+package etag_test
+
+import (
+	"testing"
+
+	"codewithdell/backend/internal/etag"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFormatAndParse tests that versions round trip through entity tags
+func TestFormatAndParse(t *testing.T) {
+	assert.Equal(t, `"7"`, etag.Format(7))
+
+	version, ok := etag.Parse(etag.Format(7))
+	assert.True(t, ok)
+	assert.Equal(t, 7, version)
+
+	for _, tag := range []string{"7", `W/"7"`, `"seven"`, `"`, `"-7"`} {
+		_, ok := etag.Parse(tag)
+		assert.False(t, ok, tag)
+	}
+}
+
+// TestFormatBody tests that body tags change with the body and keep the version
+func TestFormatBody(t *testing.T) {
+	tag := etag.FormatBody(7, []byte(`{"posts":[]}`))
+	assert.Equal(t, tag, etag.FormatBody(7, []byte(`{"posts":[]}`)))
+	assert.NotEqual(t, tag, etag.FormatBody(7, []byte(`{"posts":[1]}`)))
+	assert.NotEqual(t, tag, etag.FormatBody(8, []byte(`{"posts":[]}`)))
+
+	version, ok := etag.Parse(tag)
+	assert.True(t, ok)
+	assert.Equal(t, 7, version)
+	assert.True(t, etag.Match(tag, 7))
+}
+
+// TestMatchUsesStrongComparison tests If-Match lists, wildcards and weak tags
+func TestMatchUsesStrongComparison(t *testing.T) {
+	assert.True(t, etag.Match(`"3"`, 3))
+	assert.True(t, etag.Match(`"1", "3"`, 3))
+	assert.True(t, etag.Match("*", 3))
+	assert.False(t, etag.Match(`"2"`, 3))
+	assert.False(t, etag.Match(`W/"3"`, 3))
+	assert.False(t, etag.Match("", 3))
+}
+
+// TestNoneMatchUsesWeakComparison tests that If-None-Match accepts weak tags
+// and compares whole tags
+func TestNoneMatchUsesWeakComparison(t *testing.T) {
+	assert.True(t, etag.NoneMatch(`W/"3"`, `"3"`))
+	assert.True(t, etag.NoneMatch(`"2", W/"3"`, `"3"`))
+	assert.True(t, etag.NoneMatch("*", `"3"`))
+	assert.False(t, etag.NoneMatch(`"4"`, `"3"`))
+	assert.False(t, etag.NoneMatch(`"3"`, `"3-0011223344556677"`))
+}
@@ -9,36 +9,22 @@ import (
 
 	"codewithdell/backend/internal/handlers"
 	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
-// MockDB is a mock database for testing
-type MockDB struct {
-	mock.Mock
-}
-
-func (m *MockDB) Where(query interface{}, args ...interface{}) *gorm.DB {
-	mockArgs := m.Called(query, args)
-	return mockArgs.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) First(dest interface{}, conds ...interface{}) *gorm.DB {
-	mockArgs := m.Called(dest, conds)
-	return mockArgs.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Create(value interface{}) *gorm.DB {
-	mockArgs := m.Called(value)
-	return mockArgs.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Error() error {
-	mockArgs := m.Called()
-	return mockArgs.Error(0)
+// createLoginUser creates an active user who logs in with a password
+func createLoginUser(t *testing.T, db *gorm.DB, email, username, password string) {
+	t.Helper()
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := models.User{Email: email, Username: username, Password: string(hashed), FirstName: "John", LastName: "Doe", Status: models.StatusActive}
+	require.NoError(t, db.Create(&user).Error)
 }
 
 // TestRegister tests user registration
@@ -50,7 +36,7 @@ func TestRegister(t *testing.T) {
 		requestBody    handlers.RegisterRequest
 		expectedStatus int
 		expectedError  string
-		setupMock      func(*MockDB)
+		setup          func(*testing.T, *gorm.DB)
 	}{
 		{
 			name: "Valid registration",
@@ -62,19 +48,7 @@ func TestRegister(t *testing.T) {
 				Username:  "johndoe",
 			},
 			expectedStatus: http.StatusCreated,
-			setupMock: func(mockDB *MockDB) {
-				// Mock user existence check (user doesn't exist)
-				mockDB.On("Where", "email = ? OR username = ?", "john@example.com", "johndoe").
-					Return(mockDB)
-				mockDB.On("First", mock.Anything, mock.Anything).
-					Return(mockDB)
-				mockDB.On("Error").Return(gorm.ErrRecordNotFound)
-
-				// Mock user creation
-				mockDB.On("Create", mock.Anything).
-					Return(mockDB)
-				mockDB.On("Error").Return(nil)
-			},
+			setup:          func(t *testing.T, db *gorm.DB) {},
 		},
 		{
 			name: "User already exists",
@@ -87,13 +61,8 @@ func TestRegister(t *testing.T) {
 			},
 			expectedStatus: http.StatusConflict,
 			expectedError:  "User already exists",
-			setupMock: func(mockDB *MockDB) {
-				// Mock user existence check (user exists)
-				mockDB.On("Where", "email = ? OR username = ?", "existing@example.com", "existinguser").
-					Return(mockDB)
-				mockDB.On("First", mock.Anything, mock.Anything).
-					Return(mockDB)
-				mockDB.On("Error").Return(nil)
+			setup: func(t *testing.T, db *gorm.DB) {
+				createLoginUser(t, db, "existing@example.com", "existinguser", "Password123!")
 			},
 		},
 		{
@@ -106,7 +75,7 @@ func TestRegister(t *testing.T) {
 				Username:  "johndoe",
 			},
 			expectedStatus: http.StatusBadRequest,
-			setupMock:      func(mockDB *MockDB) {},
+			setup:          func(t *testing.T, db *gorm.DB) {},
 		},
 		{
 			name: "Weak password",
@@ -118,19 +87,19 @@ func TestRegister(t *testing.T) {
 				Username:  "johndoe",
 			},
 			expectedStatus: http.StatusBadRequest,
-			setupMock:      func(mockDB *MockDB) {},
+			setup:          func(t *testing.T, db *gorm.DB) {},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
-			mockDB := &MockDB{}
-			tt.setupMock(mockDB)
+			db := testdb.Open(t)
+			tt.setup(t, db)
 
 			router := gin.New()
 			router.Use(func(c *gin.Context) {
-				c.Set("db", mockDB)
+				c.Set("db", db)
 				c.Next()
 			})
 
@@ -164,8 +133,6 @@ func TestRegister(t *testing.T) {
 				assert.Equal(t, tt.requestBody.Email, response.User.Email)
 				assert.Equal(t, tt.requestBody.Username, response.User.Username)
 			}
-
-			mockDB.AssertExpectations(t)
 		})
 	}
 }
@@ -179,7 +146,7 @@ func TestLogin(t *testing.T) {
 	requestBody    handlers.AuthRequest
 	expectedStatus int
 	expectedError  string
-	setupMock      func(*MockDB)
+	setup          func(*testing.T, *gorm.DB)
 }{
 	{
 		name: "Valid login",
@@ -188,13 +155,8 @@ func TestLogin(t *testing.T) {
 			Password: "Password123!",
 		},
 		expectedStatus: http.StatusOK,
-		setupMock: func(mockDB *MockDB) {
-			// Mock user lookup
-			mockDB.On("Where", "email = ?", "john@example.com").
-				Return(mockDB)
-			mockDB.On("First", mock.Anything, mock.Anything).
-				Return(mockDB)
-			mockDB.On("Error").Return(nil)
+		setup: func(t *testing.T, db *gorm.DB) {
+			createLoginUser(t, db, "john@example.com", "johndoe", "Password123!")
 		},
 	},
 	{
@@ -205,14 +167,7 @@ func TestLogin(t *testing.T) {
 		},
 		expectedStatus: http.StatusUnauthorized,
 		expectedError:  "Invalid credentials",
-		setupMock: func(mockDB *MockDB) {
-			// Mock user lookup (user not found)
-			mockDB.On("Where", "email = ?", "nonexistent@example.com").
-				Return(mockDB)
-			mockDB.On("First", mock.Anything, mock.Anything).
-				Return(mockDB)
-			mockDB.On("Error").Return(gorm.ErrRecordNotFound)
-		},
+		setup:          func(t *testing.T, db *gorm.DB) {},
 	},
 	{
 		name: "Invalid email format",
@@ -221,19 +176,19 @@ func TestLogin(t *testing.T) {
 			Password: "Password123!",
 		},
 		expectedStatus: http.StatusBadRequest,
-		setupMock:      func(mockDB *MockDB) {},
+		setup:          func(t *testing.T, db *gorm.DB) {},
 	},
 }
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
-			mockDB := &MockDB{}
-			tt.setupMock(mockDB)
+			db := testdb.Open(t)
+			tt.setup(t, db)
 
 			router := gin.New()
 			router.Use(func(c *gin.Context) {
-				c.Set("db", mockDB)
+				c.Set("db", db)
 				c.Next()
 			})
 
@@ -265,8 +220,6 @@ func TestLogin(t *testing.T) {
 				assert.NotEmpty(t, response.Token)
 				assert.NotEmpty(t, response.RefreshToken)
 			}
-
-			mockDB.AssertExpectations(t)
 		})
 	}
 }
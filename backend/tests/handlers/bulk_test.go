@@ -0,0 +1,179 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// bulkResponse is the body of a bulk operation response
+type bulkResponse struct {
+	Applied bool                      `json:"applied"`
+	DryRun  bool                      `json:"dry_run"`
+	Error   string                    `json:"error"`
+	Results []handlers.BulkItemResult `json:"results"`
+	Summary map[string]int            `json:"summary"`
+}
+
+// bulk sends a bulk request for posts
+func bulk(t *testing.T, db *gorm.DB, set gin.H, body handlers.BulkRequest) (int, bulkResponse) {
+	t.Helper()
+	router := newRouter(db, set)
+	router.POST("/bulk/:type", handlers.BulkContent)
+
+	var response bulkResponse
+	w := request(t, router, http.MethodPost, "/bulk/posts", body, &response)
+	return w.Code, response
+}
+
+// reload returns the stored state of a post
+func reload(t *testing.T, db *gorm.DB, id uint) models.Post {
+	t.Helper()
+	var post models.Post
+	require.NoError(t, db.First(&post, id).Error)
+	return post
+}
+
+// TestBulkSetStatus tests that every post is changed and versioned
+func TestBulkSetStatus(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	first := testdb.Post(t, db, author.ID, "first", models.PostStatusDraft)
+	second := testdb.Post(t, db, author.ID, "second", models.PostStatusPublished)
+
+	code, response := bulk(t, db, nil, handlers.BulkRequest{IDs: []uint{first.ID, second.ID, first.ID}, Action: "set_status", Status: "published"})
+
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, response.Applied)
+	assert.Equal(t, []handlers.BulkItemResult{
+		{ID: first.ID, Result: "updated"},
+		{ID: second.ID, Result: "unchanged"},
+	}, response.Results)
+
+	published := reload(t, db, first.ID)
+	assert.Equal(t, models.PostStatusPublished, published.Status)
+	assert.NotNil(t, published.PublishedAt)
+	assert.Equal(t, first.Version+1, published.Version)
+	assert.Equal(t, second.Version, reload(t, db, second.ID).Version)
+}
+
+// TestBulkRollsBackOnFailedItem tests that no item is changed when one fails
+func TestBulkRollsBackOnFailedItem(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	later := time.Now().Add(24 * time.Hour)
+	dated := testdb.Post(t, db, author.ID, "dated", models.PostStatusDraft)
+	require.NoError(t, db.Model(&dated).Update("published_at", later).Error)
+	undated := testdb.Post(t, db, author.ID, "undated", models.PostStatusDraft)
+
+	code, response := bulk(t, db, nil, handlers.BulkRequest{IDs: []uint{dated.ID, undated.ID}, Action: "set_status", Status: "scheduled"})
+
+	require.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.False(t, response.Applied)
+	assert.NotEmpty(t, response.Error)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "updated", response.Results[0].Result)
+	assert.Equal(t, "failed", response.Results[1].Result)
+	assert.NotEmpty(t, response.Results[1].Error)
+
+	assert.Equal(t, models.PostStatusDraft, reload(t, db, dated.ID).Status)
+	assert.Equal(t, dated.Version, reload(t, db, dated.ID).Version)
+}
+
+// TestBulkUnknownIDs tests that unknown and deleted posts fail the operation
+func TestBulkUnknownIDs(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	trashed := testdb.Post(t, db, author.ID, "trashed", models.PostStatusPublished)
+	testdb.Trash(t, db, "posts", trashed.ID, time.Now())
+
+	code, response := bulk(t, db, nil, handlers.BulkRequest{IDs: []uint{post.ID, trashed.ID, 999}, Action: "delete"})
+
+	require.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, map[string]int{"updated": 1, "unchanged": 0, "not_found": 2, "failed": 0}, response.Summary)
+	assert.False(t, testdb.Deleted(t, db, "posts", post.ID))
+}
+
+// TestBulkDryRun tests that a dry run reports the changes without making them
+func TestBulkDryRun(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	tag := models.Tag{Name: "go", Slug: "go"}
+	require.NoError(t, db.Create(&tag).Error)
+
+	code, response := bulk(t, db, nil, handlers.BulkRequest{IDs: []uint{post.ID}, Action: "add_tags", TagIDs: []uint{tag.ID}, DryRun: true})
+
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, response.DryRun)
+	assert.False(t, response.Applied)
+	assert.Equal(t, []handlers.BulkItemResult{{ID: post.ID, Result: "updated"}}, response.Results)
+
+	var links int64
+	require.NoError(t, db.Table("post_tags").Count(&links).Error)
+	assert.Zero(t, links)
+	assert.Equal(t, post.Version, reload(t, db, post.ID).Version)
+}
+
+// TestBulkLintBlocksPublishing tests that posts with lint errors fail to
+// publish when lint errors block publishing
+func TestBulkLintBlocksPublishing(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	clean := testdb.Post(t, db, author.ID, "clean", models.PostStatusDraft)
+	broken := testdb.Post(t, db, author.ID, "broken", models.PostStatusDraft)
+	require.NoError(t, db.Model(&broken).Update("content", "See [this](/blog/missing) post.").Error)
+	req := handlers.BulkRequest{IDs: []uint{clean.ID, broken.ID}, Action: "set_status", Status: "published"}
+
+	code, response := bulk(t, db, gin.H{"lint_blocks_publish": true}, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, code)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "updated", response.Results[0].Result)
+	assert.Equal(t, handlers.BulkItemResult{ID: broken.ID, Result: "failed", Error: "post has 1 lint errors"}, response.Results[1])
+	assert.Equal(t, models.PostStatusDraft, reload(t, db, clean.ID).Status)
+
+	// Without the policy the same posts are published
+	code, _ = bulk(t, db, nil, req)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, models.PostStatusPublished, reload(t, db, broken.ID).Status)
+}
+
+// TestBulkSetAuthor tests that the new author takes over the credit of the
+// previous one and loses their other credit
+func TestBulkSetAuthor(t *testing.T) {
+	db := testdb.Open(t)
+	alice := testdb.User(t, db, "alice")
+	bob := testdb.User(t, db, "bob")
+	carol := testdb.User(t, db, "carol")
+	post := testdb.Post(t, db, alice.ID, "post", models.PostStatusPublished)
+	require.NoError(t, db.Create(&[]models.Contributor{
+		{UserID: bob.ID, PostID: &post.ID, Role: models.ContributorRoleEditor, Position: 1},
+		{UserID: carol.ID, PostID: &post.ID, Role: models.ContributorRoleReviewer, Position: 2},
+	}).Error)
+
+	code, response := bulk(t, db, nil, handlers.BulkRequest{IDs: []uint{post.ID}, Action: "set_author", AuthorID: bob.ID})
+
+	require.Equal(t, http.StatusOK, code, response.Error)
+	updated := reload(t, db, post.ID)
+	assert.Equal(t, bob.ID, updated.AuthorID)
+	assert.Equal(t, post.Version+1, updated.Version)
+
+	var contributors []models.Contributor
+	require.NoError(t, db.Where("post_id = ?", post.ID).Order("position ASC").Find(&contributors).Error)
+	require.Len(t, contributors, 2)
+	assert.Equal(t, bob.ID, contributors[0].UserID)
+	assert.Equal(t, models.ContributorRoleAuthor, contributors[0].Role)
+	assert.Equal(t, carol.ID, contributors[1].UserID)
+	assert.Equal(t, models.ContributorRoleReviewer, contributors[1].Role)
+}
@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// credits returns the usernames and roles of contributors in order
+func credits(contributors []models.Contributor) []string {
+	list := []string{}
+	for _, contributor := range contributors {
+		list = append(list, contributor.User.Username+":"+string(contributor.Role))
+	}
+	return list
+}
+
+// TestPostContributors tests that the first author of the contributor list
+// becomes the post author and that invalid lists are rejected
+func TestPostContributors(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	alice := testdb.User(t, db, "alice")
+	bob := testdb.User(t, db, "bob")
+
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(admin.ID), "role": string(models.RoleAdmin)})
+	router.POST("/posts", handlers.CreatePost)
+	router.PUT("/posts/:id", handlers.UpdatePost)
+
+	post := gin.H{"title": "Pairing", "content": "Written by two people.", "status": "published"}
+	for _, contributors := range [][]gin.H{
+		{{"user_id": bob.ID, "role": "editor"}},
+		{{"user_id": alice.ID}, {"user_id": alice.ID, "role": "editor"}},
+		{{"user_id": 999}},
+	} {
+		post["contributors"] = contributors
+		assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodPost, "/posts", post, nil).Code, contributors)
+	}
+
+	post["contributors"] = []gin.H{{"user_id": bob.ID, "role": "editor"}, {"user_id": alice.ID}}
+	var created models.Post
+	w := request(t, router, http.MethodPost, "/posts", post, &created)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, alice.ID, created.AuthorID)
+	assert.Equal(t, []string{"bob:editor", "alice:author"}, credits(created.Contributors))
+
+	var updated models.Post
+	w = requestWith(t, router, http.MethodPut, fmt.Sprintf("/posts/%d", created.ID), anyVersion, gin.H{"contributors": []gin.H{{"user_id": bob.ID}}}, &updated)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, bob.ID, updated.AuthorID)
+	assert.Equal(t, []string{"bob:author"}, credits(updated.Contributors))
+}
+
+// TestGetUserStatsCountsContributions tests that user stats and the author
+// filter include content a user contributed to
+func TestGetUserStatsCountsContributions(t *testing.T) {
+	db := testdb.Open(t)
+	alice := testdb.User(t, db, "alice")
+	bob := testdb.User(t, db, "bob")
+	post := testdb.Post(t, db, alice.ID, "pairing", models.PostStatusPublished)
+	project := testdb.Project(t, db, alice.ID, "tool", models.ProjectStatusPublished)
+	testdb.Post(t, db, alice.ID, "solo", models.PostStatusPublished)
+	draft := testdb.Post(t, db, alice.ID, "draft", models.PostStatusDraft)
+	for _, contributor := range []models.Contributor{
+		{UserID: bob.ID, PostID: &post.ID, Role: models.ContributorRoleEditor},
+		{UserID: bob.ID, ProjectID: &project.ID, Role: models.ContributorRoleIllustrator},
+		{UserID: bob.ID, PostID: &draft.ID, Role: models.ContributorRoleAuthor},
+	} {
+		require.NoError(t, db.Omit("User").Create(&contributor).Error)
+	}
+
+	router := newRouter(db, nil)
+	router.GET("/users/:id/stats", handlers.GetUserStats)
+
+	var stats struct {
+		PostCount    int64     `json:"post_count"`
+		ProjectCount int64     `json:"project_count"`
+		RecentPosts  []slugged `json:"recent_posts"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, fmt.Sprintf("/users/%d/stats", bob.ID), nil, &stats).Code)
+	assert.Equal(t, int64(1), stats.PostCount)
+	assert.Equal(t, int64(1), stats.ProjectCount)
+	assert.Equal(t, []string{"pairing"}, slugsOf(stats.RecentPosts))
+
+	request(t, router, http.MethodGet, fmt.Sprintf("/users/%d/stats", alice.ID), nil, &stats)
+	assert.Equal(t, int64(2), stats.PostCount)
+
+	response, _, _ := listPosts(t, db, "", "author=bob")
+	assert.Equal(t, []string{"pairing"}, slugsOf(response.Posts))
+}
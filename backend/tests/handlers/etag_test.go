@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getIfNoneMatch sends a GET request with an If-None-Match header, when given
+func getIfNoneMatch(t *testing.T, router *gin.Engine, path, tag string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	require.NoError(t, err)
+	if tag != "" {
+		req.Header.Set("If-None-Match", tag)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestCategoryETagCoversPosts tests that the ETag of a category changes when
+// its posts change, although the category itself does not
+func TestCategoryETagCoversPosts(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	category := models.Category{Name: "Go", Slug: "go"}
+	require.NoError(t, db.Create(&category).Error)
+
+	router := newRouter(db, nil)
+	router.GET("/categories/:slug", handlers.GetCategoryBySlug)
+
+	first := getIfNoneMatch(t, router, "/categories/go", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	tag := first.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+	assert.Contains(t, first.Header().Values("Vary"), "Accept-Language")
+	assert.Equal(t, http.StatusNotModified, getIfNoneMatch(t, router, "/categories/go", tag).Code)
+
+	require.NoError(t, db.Exec("INSERT INTO post_categories (post_id, category_id) VALUES (?, ?)", post.ID, category.ID).Error)
+	linked := getIfNoneMatch(t, router, "/categories/go", tag)
+	require.Equal(t, http.StatusOK, linked.Code)
+	assert.NotEqual(t, tag, linked.Header().Get("ETag"))
+	assert.Contains(t, linked.Body.String(), `"slug":"post"`)
+
+	require.NoError(t, db.Model(&post).Update("title", "Renamed").Error)
+	renamed := getIfNoneMatch(t, router, "/categories/go", linked.Header().Get("ETag"))
+	assert.Equal(t, http.StatusOK, renamed.Code)
+}
+
+// TestPostETagCoversAuthor tests that the ETag of a post changes with its
+// author but not with its view count
+func TestPostETagCoversAuthor(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+
+	router := newRouter(db, nil)
+	router.GET("/posts/:slug", handlers.GetPostBySlug)
+
+	first := getIfNoneMatch(t, router, "/posts/post", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	tag := first.Header().Get("ETag")
+	assert.Equal(t, http.StatusNotModified, getIfNoneMatch(t, router, "/posts/post", tag).Code)
+
+	require.NoError(t, db.Model(&author).Update("first_name", "Renamed").Error)
+	renamed := getIfNoneMatch(t, router, "/posts/post", tag)
+	assert.Equal(t, http.StatusOK, renamed.Code)
+	assert.NotEqual(t, tag, renamed.Header().Get("ETag"))
+}
+
+// TestSavedETagFormat tests that create and update responses carry ETags in
+// the same format as reads, which later updates can send back as If-Match
+func TestSavedETagFormat(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(admin.ID), "role": string(models.RoleAdmin)})
+	router.GET("/tags/:slug", handlers.GetTagBySlug)
+	router.POST("/tags", handlers.CreateTag)
+	router.PUT("/tags/:id", handlers.UpdateTag)
+
+	var created struct {
+		Tag models.Tag `json:"tag"`
+	}
+	w := request(t, router, http.MethodPost, "/tags", gin.H{"name": "Go"}, &created)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	format := `^"\d+-[0-9a-f]{16}"$`
+	assert.Regexp(t, format, w.Header().Get("ETag"))
+
+	read := getIfNoneMatch(t, router, "/tags/go", "")
+	require.Equal(t, http.StatusOK, read.Code)
+	assert.Regexp(t, format, read.Header().Get("ETag"))
+
+	path := fmt.Sprintf("/tags/%d", created.Tag.ID)
+	first := http.Header{"If-Match": {w.Header().Get("ETag")}}
+	w = requestWith(t, router, http.MethodPut, path, first, gin.H{"name": "Golang"}, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Regexp(t, format, w.Header().Get("ETag"))
+
+	second := http.Header{"If-Match": {w.Header().Get("ETag")}}
+	assert.Equal(t, http.StatusPreconditionFailed, requestWith(t, router, http.MethodPut, path, first, gin.H{"name": "Go"}, nil).Code)
+	assert.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, path, second, gin.H{"name": "Go"}, nil).Code)
+}
@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// featureSlot adds a slot for a post or project to the featured rail
+func featureSlot(t *testing.T, db *gorm.DB, slot models.FeaturedSlot) models.FeaturedSlot {
+	t.Helper()
+	slot.Type = models.FeaturedTypePost
+	if slot.ProjectID != nil {
+		slot.Type = models.FeaturedTypeProject
+	}
+	require.NoError(t, db.Omit(clause.Associations).Create(&slot).Error)
+	return slot
+}
+
+// featuredResponse is the featured rail returned by GetFeatured
+type featuredResponse struct {
+	Items []struct {
+		SlotID  uint     `json:"slot_id"`
+		Type    string   `json:"type"`
+		Post    *slugged `json:"post"`
+		Project *slugged `json:"project"`
+	} `json:"items"`
+	Total int `json:"total"`
+}
+
+// TestGetFeaturedShowsPublishedContentInOrder tests that the rail lists the
+// published content of running slots by position, each only once
+func TestGetFeaturedShowsPublishedContentInOrder(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	first := testdb.Post(t, db, author.ID, "first", models.PostStatusPublished)
+	second := testdb.Post(t, db, author.ID, "second", models.PostStatusPublished)
+	draft := testdb.Post(t, db, author.ID, "draft", models.PostStatusDraft)
+	project := models.Project{Title: "Tool", Slug: "tool", Content: "A tool.", Status: models.ProjectStatusPublished, AuthorID: author.ID}
+	require.NoError(t, db.Omit(clause.Associations).Create(&project).Error)
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	secondSlot := featureSlot(t, db, models.FeaturedSlot{PostID: &second.ID, Position: 0})
+	projectSlot := featureSlot(t, db, models.FeaturedSlot{ProjectID: &project.ID, Position: 1, StartsAt: &past, EndsAt: &future})
+	firstSlot := featureSlot(t, db, models.FeaturedSlot{PostID: &first.ID, Position: 2})
+	featureSlot(t, db, models.FeaturedSlot{PostID: &second.ID, Position: 3})
+	featureSlot(t, db, models.FeaturedSlot{PostID: &draft.ID, Position: 4})
+	featureSlot(t, db, models.FeaturedSlot{PostID: &first.ID, Position: 5, StartsAt: &future})
+	featureSlot(t, db, models.FeaturedSlot{ProjectID: &project.ID, Position: 6, EndsAt: &past})
+
+	router := newRouter(db, nil)
+	router.GET("/featured", handlers.GetFeatured)
+
+	var response featuredResponse
+	w := request(t, router, http.MethodGet, "/featured", nil, &response)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 3, response.Total)
+	require.Len(t, response.Items, 3)
+
+	assert.Equal(t, secondSlot.ID, response.Items[0].SlotID)
+	require.NotNil(t, response.Items[0].Post)
+	assert.Equal(t, "second", response.Items[0].Post.Slug)
+
+	assert.Equal(t, projectSlot.ID, response.Items[1].SlotID)
+	assert.Equal(t, "project", response.Items[1].Type)
+	require.NotNil(t, response.Items[1].Project)
+	assert.Equal(t, "tool", response.Items[1].Project.Slug)
+
+	assert.Equal(t, firstSlot.ID, response.Items[2].SlotID)
+	require.NotNil(t, response.Items[2].Post)
+	assert.Equal(t, "first", response.Items[2].Post.Slug)
+}
+
+// TestReorderFeaturedSlots tests that the rail takes the order of the
+// request, which has to list every slot once
+func TestReorderFeaturedSlots(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	var slots []models.FeaturedSlot
+	for i, slug := range []string{"a", "b", "c"} {
+		post := testdb.Post(t, db, author.ID, slug, models.PostStatusPublished)
+		slots = append(slots, featureSlot(t, db, models.FeaturedSlot{PostID: &post.ID, Position: i}))
+	}
+
+	router := newRouter(db, nil)
+	router.PUT("/featured/order", handlers.ReorderFeaturedSlots)
+	router.GET("/featured", handlers.GetFeatured)
+
+	for _, ids := range [][]uint{
+		{slots[2].ID, slots[0].ID},
+		{slots[2].ID, slots[0].ID, slots[0].ID},
+		{slots[2].ID, slots[0].ID, slots[1].ID, slots[1].ID + 100},
+	} {
+		w := request(t, router, http.MethodPut, "/featured/order", gin.H{"slot_ids": ids}, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, ids)
+	}
+
+	w := request(t, router, http.MethodPut, "/featured/order", gin.H{"slot_ids": []uint{slots[2].ID, slots[0].ID, slots[1].ID}}, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response featuredResponse
+	request(t, router, http.MethodGet, "/featured", nil, &response)
+	var order []string
+	for _, item := range response.Items {
+		order = append(order, item.Post.Slug)
+	}
+	assert.Equal(t, []string{"c", "a", "b"}, order)
+}
@@ -0,0 +1,74 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newRouter returns a router that provides db and the context values in set
+// to its handlers
+func newRouter(db *gorm.DB, set gin.H) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("db", db)
+		for key, value := range set {
+			c.Set(key, value)
+		}
+		c.Next()
+	})
+	return router
+}
+
+// anyVersion is an If-Match header that matches every version of content
+var anyVersion = http.Header{"If-Match": {"*"}}
+
+// request sends a JSON request and decodes the JSON response into out
+func request(t *testing.T, router *gin.Engine, method, path string, body interface{}, out interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return requestWith(t, router, method, path, nil, body, out)
+}
+
+// requestWith sends a JSON request with extra headers and decodes the JSON
+// response into out
+func requestWith(t *testing.T, router *gin.Engine, method, path string, header http.Header, body interface{}, out interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reader).Encode(body))
+	}
+	req, err := http.NewRequest(method, path, &reader)
+	require.NoError(t, err)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if out != nil {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), out), w.Body.String())
+	}
+	return w
+}
+
+// slugged is the part of a post or project response the tests look at
+type slugged struct {
+	Slug string `json:"slug"`
+}
+
+// slugsOf returns the slugs of a list of responses
+func slugsOf(items []slugged) []string {
+	slugs := []string{}
+	for _, item := range items {
+		slugs = append(slugs, item.Slug)
+	}
+	return slugs
+}
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// interactionRouter serves the interaction endpoints for user
+func interactionRouter(db *gorm.DB, user models.User) *gin.Engine {
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(user.ID)})
+	router.POST("/interactions/:type/:id/like", handlers.LikeContent)
+	router.DELETE("/interactions/:type/:id/like", handlers.UnlikeContent)
+	router.POST("/interactions/:type/:id/bookmark", handlers.BookmarkContent)
+	router.DELETE("/interactions/:type/:id/bookmark", handlers.RemoveBookmark)
+	router.GET("/interactions/:type/:id/check", handlers.CheckUserInteraction)
+	router.GET("/interactions/likes", handlers.GetUserLikes)
+	router.GET("/interactions/bookmarks", handlers.GetUserBookmarks)
+	return router
+}
+
+// interactionsResponse is a list of liked or bookmarked content
+type interactionsResponse struct {
+	Items []struct {
+		Type    string   `json:"type"`
+		Post    *slugged `json:"post"`
+		Project *slugged `json:"project"`
+	} `json:"items"`
+	Total int `json:"total"`
+}
+
+// TestLikePostsAndProjects tests that posts and projects are liked once
+// each and that their like counts follow
+func TestLikePostsAndProjects(t *testing.T) {
+	db := testdb.Open(t)
+	user := testdb.User(t, db, "reader")
+	post := testdb.Post(t, db, user.ID, "post", models.PostStatusPublished)
+	project := testdb.Project(t, db, user.ID, "tool", models.ProjectStatusPublished)
+	router := interactionRouter(db, user)
+
+	postPath := fmt.Sprintf("/interactions/posts/%d/like", post.ID)
+	projectPath := fmt.Sprintf("/interactions/projects/%d/like", project.ID)
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodPost, postPath, nil, nil).Code)
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodPost, projectPath, nil, nil).Code)
+	assert.Equal(t, http.StatusConflict, request(t, router, http.MethodPost, projectPath, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodPost, "/interactions/projects/999/like", nil, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodPost, "/interactions/videos/1/like", nil, nil).Code)
+
+	var stored models.Project
+	require.NoError(t, db.First(&stored, project.ID).Error)
+	assert.Equal(t, 1, stored.LikeCount)
+
+	var likes interactionsResponse
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/interactions/likes", nil, &likes).Code)
+	require.Equal(t, 2, likes.Total)
+	request(t, router, http.MethodGet, "/interactions/likes?type=project", nil, &likes)
+	require.Len(t, likes.Items, 1)
+	assert.Equal(t, "project", likes.Items[0].Type)
+	require.NotNil(t, likes.Items[0].Project)
+	assert.Equal(t, "tool", likes.Items[0].Project.Slug)
+	assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodGet, "/interactions/likes?type=video", nil, nil).Code)
+
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodDelete, projectPath, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodDelete, projectPath, nil, nil).Code)
+	require.NoError(t, db.First(&stored, project.ID).Error)
+	assert.Zero(t, stored.LikeCount)
+}
+
+// TestBookmarkProjects tests bookmarking a project and checking the
+// interactions of the user with it
+func TestBookmarkProjects(t *testing.T) {
+	db := testdb.Open(t)
+	user := testdb.User(t, db, "reader")
+	post := testdb.Post(t, db, user.ID, "post", models.PostStatusPublished)
+	project := testdb.Project(t, db, user.ID, "tool", models.ProjectStatusPublished)
+	router := interactionRouter(db, user)
+
+	path := fmt.Sprintf("/interactions/projects/%d/bookmark", project.ID)
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodPost, path, nil, nil).Code)
+	assert.Equal(t, http.StatusConflict, request(t, router, http.MethodPost, path, nil, nil).Code)
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodPost, fmt.Sprintf("/interactions/posts/%d/bookmark", post.ID), nil, nil).Code)
+
+	var check struct {
+		Type         string `json:"type"`
+		IsLiked      bool   `json:"is_liked"`
+		IsBookmarked bool   `json:"is_bookmarked"`
+	}
+	request(t, router, http.MethodGet, fmt.Sprintf("/interactions/projects/%d/check", project.ID), nil, &check)
+	assert.Equal(t, "project", check.Type)
+	assert.False(t, check.IsLiked)
+	assert.True(t, check.IsBookmarked)
+
+	// A deleted project drops out of the bookmarks
+	require.NoError(t, db.Delete(&project).Error)
+	var bookmarks interactionsResponse
+	request(t, router, http.MethodGet, "/interactions/bookmarks", nil, &bookmarks)
+	require.Len(t, bookmarks.Items, 1)
+	assert.Equal(t, "post", bookmarks.Items[0].Type)
+
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodDelete, path, nil, nil).Code)
+	request(t, router, http.MethodGet, fmt.Sprintf("/interactions/projects/%d/check", project.ID), nil, &check)
+	assert.False(t, check.IsBookmarked)
+}
@@ -0,0 +1,255 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/lint"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// postListResponse is a page of the post listing
+type postListResponse struct {
+	Posts      []slugged `json:"posts"`
+	Total      int64     `json:"total"`
+	Page       int       `json:"page"`
+	Pages      int       `json:"pages"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+// listPosts requests the post listing with a query as a user with role
+func listPosts(t *testing.T, db *gorm.DB, role, query string) (*postListResponse, http.Header, int) {
+	t.Helper()
+	router := newRouter(db, gin.H{"role": role})
+	router.GET("/posts", handlers.GetPosts)
+	var response postListResponse
+	w := request(t, router, http.MethodGet, "/posts?"+query, nil, &response)
+	return &response, w.Header(), w.Code
+}
+
+// adminPostRouter serves post creation and updates to an admin
+func adminPostRouter(db *gorm.DB, admin models.User) *gin.Engine {
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(admin.ID), "role": string(models.RoleAdmin)})
+	router.POST("/posts", handlers.CreatePost)
+	router.PUT("/posts/:id", handlers.UpdatePost)
+	return router
+}
+
+// createTags creates tags named after their slugs and returns their ids
+func createTags(t *testing.T, db *gorm.DB, slugs ...string) map[string]string {
+	t.Helper()
+	ids := make(map[string]string, len(slugs))
+	for _, slug := range slugs {
+		tag := models.Tag{Name: slug, Slug: slug}
+		require.NoError(t, db.Create(&tag).Error)
+		ids[slug] = fmt.Sprint(tag.ID)
+	}
+	return ids
+}
+
+// tagSlugs returns the slugs of tags in order
+func tagSlugs(tags []models.Tag) []string {
+	slugs := []string{}
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	return slugs
+}
+
+// popularPosts creates published posts with view counts, named by slug
+func popularPosts(t *testing.T, db *gorm.DB, authorID uint, views map[string]int) {
+	t.Helper()
+	for slug, count := range views {
+		post := testdb.Post(t, db, authorID, slug, models.PostStatusPublished)
+		require.NoError(t, db.Model(&post).UpdateColumn("view_count", count).Error)
+	}
+}
+
+// TestGetPostsPaginatesByPage tests page pagination and its headers
+func TestGetPostsPaginatesByPage(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	popularPosts(t, db, author.ID, map[string]int{"a": 50, "b": 40, "c": 30, "d": 20, "e": 10})
+	testdb.Post(t, db, author.ID, "draft", models.PostStatusDraft)
+
+	response, header, code := listPosts(t, db, "", "sort=popular&limit=2&page=2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"c", "d"}, slugsOf(response.Posts))
+	assert.Equal(t, int64(5), response.Total)
+	assert.Equal(t, 2, response.Page)
+	assert.Equal(t, 3, response.Pages)
+	assert.NotEmpty(t, response.NextCursor)
+
+	assert.Equal(t, "5", header.Get("X-Total-Count"))
+	assert.Equal(t, "2", header.Get("X-Per-Page"))
+	assert.Equal(t, "3", header.Get("X-Page-Count"))
+	assert.Equal(t, "2", header.Get("X-Current-Page"))
+
+	response, _, _ = listPosts(t, db, "", "sort=popular&limit=2&page=3")
+	assert.Equal(t, []string{"e"}, slugsOf(response.Posts))
+	assert.Empty(t, response.NextCursor)
+
+	_, header, _ = listPosts(t, db, "", "limit=500")
+	assert.Equal(t, "50", header.Get("X-Per-Page"))
+}
+
+// TestGetPostsPaginatesByCursor tests that following next_cursor walks every
+// post once, breaking ties by id, without page headers
+func TestGetPostsPaginatesByCursor(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	for _, slug := range []string{"a", "b", "c", "d", "e"} {
+		testdb.Post(t, db, author.ID, slug, models.PostStatusPublished)
+	}
+	require.NoError(t, db.Model(&models.Post{}).Where("slug IN ?", []string{"b", "d"}).UpdateColumn("view_count", 5).Error)
+
+	var seen []string
+	query := "sort=popular&limit=2&cursor="
+	for pages := 0; pages < 5; pages++ {
+		response, header, code := listPosts(t, db, "", query)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "5", header.Get("X-Total-Count"))
+		assert.Empty(t, header.Get("X-Page-Count"))
+		assert.Empty(t, header.Get("X-Current-Page"))
+		assert.Zero(t, response.Page)
+		seen = append(seen, slugsOf(response.Posts)...)
+		if response.NextCursor == "" {
+			break
+		}
+		query = "sort=popular&limit=2&cursor=" + response.NextCursor
+	}
+	assert.Equal(t, []string{"d", "b", "e", "c", "a"}, seen)
+
+	first, _, _ := listPosts(t, db, "", "sort=popular&limit=2")
+	for _, query := range []string{"cursor=garbage", "sort=title&cursor=" + first.NextCursor, "sort=random"} {
+		_, _, code := listPosts(t, db, "", query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
+
+// TestGetPostsStatusRequiresAdmin tests that only admins can list posts that
+// are not published
+func TestGetPostsStatusRequiresAdmin(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	testdb.Post(t, db, author.ID, "published", models.PostStatusPublished)
+	testdb.Post(t, db, author.ID, "draft", models.PostStatusDraft)
+	testdb.Post(t, db, author.ID, "archived", models.PostStatusArchived)
+
+	for _, role := range []string{"", string(models.RoleUser)} {
+		_, _, code := listPosts(t, db, role, "status=draft")
+		assert.Equal(t, http.StatusForbidden, code, role)
+	}
+
+	admin := string(models.RoleAdmin)
+	response, _, _ := listPosts(t, db, admin, "status=draft")
+	assert.Equal(t, []string{"draft"}, slugsOf(response.Posts))
+	response, _, _ = listPosts(t, db, admin, "status=all&sort=title")
+	assert.Equal(t, []string{"archived", "draft", "published"}, slugsOf(response.Posts))
+	response, _, _ = listPosts(t, db, admin, "")
+	assert.Equal(t, []string{"published"}, slugsOf(response.Posts))
+
+	_, _, code := listPosts(t, db, admin, "status=deleted")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+// TestGetPostsFilters tests the author, tag and date filters
+func TestGetPostsFilters(t *testing.T) {
+	db := testdb.Open(t)
+	alice := testdb.User(t, db, "alice")
+	bob := testdb.User(t, db, "bob")
+	old := testdb.Post(t, db, alice.ID, "old", models.PostStatusPublished)
+	testdb.Post(t, db, alice.ID, "new", models.PostStatusPublished)
+	tagged := testdb.Post(t, db, bob.ID, "tagged", models.PostStatusPublished)
+	require.NoError(t, db.Model(&old).Update("published_at", time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)).Error)
+	tag := models.Tag{Name: "Go", Slug: "go"}
+	require.NoError(t, db.Create(&tag).Error)
+	require.NoError(t, db.Exec("INSERT INTO post_tags (post_id, tag_id) VALUES (?, ?)", tagged.ID, tag.ID).Error)
+
+	for query, expected := range map[string][]string{
+		"author=alice":                  {"new", "old"},
+		"tags=go,rust":                  {"tagged"},
+		"tag=go&author=alice":           {},
+		"from=2023-05-01&to=2023-05-10": {"old"},
+		"from=2024-01-01":               {"new", "tagged"},
+	} {
+		response, _, code := listPosts(t, db, "", query+"&sort=title")
+		require.Equal(t, http.StatusOK, code, query)
+		assert.Equal(t, expected, slugsOf(response.Posts), query)
+	}
+
+	_, _, code := listPosts(t, db, "", "from=yesterday")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+// TestUpdatePostTagsBumpVersion tests that tags are saved along with the
+// post and that changing them alone makes a new version
+func TestUpdatePostTagsBumpVersion(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	tags := createTags(t, db, "go", "rust")
+	router := adminPostRouter(db, admin)
+
+	var post models.Post
+	w := request(t, router, http.MethodPost, "/posts", gin.H{
+		"title": "Tagged", "content": "A post with a tag.", "status": "draft", "tag_ids": []string{tags["go"]},
+	}, &post)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, []string{"go"}, tagSlugs(post.Tags))
+	version := http.Header{"If-Match": {w.Header().Get("ETag")}}
+
+	path := fmt.Sprintf("/posts/%d", post.ID)
+	w = requestWith(t, router, http.MethodPut, path, version, gin.H{"tag_ids": []string{tags["rust"]}}, &post)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"rust"}, tagSlugs(post.Tags))
+	assert.Equal(t, 2, post.Version)
+
+	w = requestWith(t, router, http.MethodPut, path, version, gin.H{"tag_ids": []string{tags["go"]}}, nil)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	var stored models.Post
+	require.NoError(t, db.Preload("Tags").First(&stored, post.ID).Error)
+	assert.Equal(t, []string{"rust"}, tagSlugs(stored.Tags))
+}
+
+// TestUpdatePostClearsTags tests that omitted tag ids keep the tags of a
+// post and an empty list clears them
+func TestUpdatePostClearsTags(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	tags := createTags(t, db, "go")
+	router := adminPostRouter(db, admin)
+	router.GET("/posts/:id/lint", handlers.LintPost)
+
+	var post models.Post
+	w := request(t, router, http.MethodPost, "/posts", gin.H{
+		"title": "Tagged", "content": "A post with a tag.", "status": "draft", "tag_ids": []string{tags["go"]},
+	}, &post)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	path := fmt.Sprintf("/posts/%d", post.ID)
+
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, path, anyVersion, gin.H{"title": "Renamed"}, &post).Code)
+	assert.Equal(t, []string{"go"}, tagSlugs(post.Tags))
+
+	var cleared models.Post
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, path, anyVersion, gin.H{"tag_ids": []string{}}, &cleared).Code)
+	assert.Empty(t, cleared.Tags)
+
+	var linted struct {
+		Report lint.Report `json:"report"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, path+"/lint", nil, &linted).Code)
+	rules := []string{}
+	for _, issue := range linted.Report.Issues {
+		rules = append(rules, issue.Rule)
+	}
+	assert.Contains(t, rules, lint.RuleMissingTags)
+}
@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// previewRouter serves the preview link endpoints to an admin
+func previewRouter(db *gorm.DB, admin models.User) *gin.Engine {
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(admin.ID), "role": string(models.RoleAdmin)})
+	router.GET("/preview/:token", handlers.GetPreview)
+	router.POST("/previews/:type/:id", handlers.CreatePreview)
+	router.GET("/previews/:type/:id", handlers.GetPreviews)
+	router.DELETE("/previews/:id", handlers.RevokePreview)
+	return router
+}
+
+// previewResponse is a newly created preview link
+type previewResponse struct {
+	Token   string              `json:"token"`
+	Preview models.PreviewToken `json:"preview"`
+}
+
+// createPreview creates a preview link for content with a request body
+func createPreview(t *testing.T, router *gin.Engine, path string, body interface{}) previewResponse {
+	t.Helper()
+	var created previewResponse
+	w := request(t, router, http.MethodPost, path, body, &created)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return created
+}
+
+// TestPreviewDraftPost tests that a preview link shows a draft with its
+// rendered content until the link is revoked
+func TestPreviewDraftPost(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	post := testdb.Post(t, db, admin.ID, "draft", models.PostStatusDraft)
+	router := previewRouter(db, admin)
+	path := fmt.Sprintf("/previews/posts/%d", post.ID)
+
+	created := createPreview(t, router, path, nil)
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), created.Preview.ExpiresAt, time.Minute)
+
+	var shown struct {
+		Type string `json:"type"`
+		Post struct {
+			Slug        string `json:"slug"`
+			ContentHTML string `json:"content_html"`
+		} `json:"post"`
+	}
+	w := request(t, router, http.MethodGet, "/preview/"+created.Token, nil, &shown)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "post", shown.Type)
+	assert.Equal(t, "draft", shown.Post.Slug)
+	assert.NotEmpty(t, shown.Post.ContentHTML)
+	assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/preview/unknown", nil, nil).Code)
+
+	var listed struct {
+		Previews []models.PreviewToken `json:"previews"`
+	}
+	request(t, router, http.MethodGet, path, nil, &listed)
+	require.Len(t, listed.Previews, 1)
+	assert.Equal(t, 1, listed.Previews[0].ViewCount)
+	assert.NotNil(t, listed.Previews[0].LastUsedAt)
+
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodDelete, fmt.Sprintf("/previews/%d", created.Preview.ID), nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/preview/"+created.Token, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodDelete, "/previews/999", nil, nil).Code)
+}
+
+// TestPreviewExpiry tests the lifetime limits of preview links and that
+// expired links stop working
+func TestPreviewExpiry(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	project := testdb.Project(t, db, admin.ID, "draft", models.ProjectStatusDraft)
+	router := previewRouter(db, admin)
+	path := fmt.Sprintf("/previews/projects/%d", project.ID)
+
+	assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodPost, path, gin.H{"expires_in_hours": 721}, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodPost, "/previews/projects/999", nil, nil).Code)
+
+	created := createPreview(t, router, path, gin.H{"expires_in_hours": 1, "note": "For review"})
+	assert.WithinDuration(t, time.Now().Add(time.Hour), created.Preview.ExpiresAt, time.Minute)
+	assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/preview/"+created.Token, nil, nil).Code)
+
+	require.NoError(t, db.Model(&models.PreviewToken{}).Where("id = ?", created.Preview.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/preview/"+created.Token, nil, nil).Code)
+}
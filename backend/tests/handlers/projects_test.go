@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// projectRouter serves the project endpoints, the admin ones as user
+func projectRouter(db *gorm.DB, user models.User) *gin.Engine {
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(user.ID), "role": string(models.RoleAdmin)})
+	router.GET("/projects", handlers.GetProjects)
+	router.GET("/projects/:slug", handlers.GetProjectBySlug)
+	router.POST("/admin/projects", handlers.CreateProject)
+	router.PUT("/admin/projects/:id", handlers.UpdateProject)
+	router.DELETE("/admin/projects/:id", handlers.DeleteProject)
+	return router
+}
+
+// TestProjectLifecycle tests creating, updating and deleting a project
+func TestProjectLifecycle(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	technology := models.Technology{Name: "Go", Slug: "go"}
+	require.NoError(t, db.Create(&technology).Error)
+	router := projectRouter(db, admin)
+
+	var created models.Project
+	w := request(t, router, http.MethodPost, "/admin/projects", gin.H{
+		"title":          "Link Shortener",
+		"description":    "Shortens links and counts clicks.",
+		"status":         "published",
+		"technology_ids": []uint{technology.ID},
+	}, &created)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "link-shortener", created.Slug)
+	assert.Equal(t, admin.ID, created.AuthorID)
+	assert.NotNil(t, created.PublishedAt)
+	assert.Equal(t, models.DifficultyIntermediate, created.Difficulty)
+	require.Len(t, created.Technologies, 1)
+	version := http.Header{"If-Match": {w.Header().Get("ETag")}}
+
+	w = request(t, router, http.MethodPost, "/admin/projects", gin.H{
+		"title": "Link Shortener", "description": "The same title again.", "status": "draft",
+	}, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Updates need the version the client last saw
+	var updated models.Project
+	path := fmt.Sprintf("/admin/projects/%d", created.ID)
+	assert.Equal(t, http.StatusPreconditionRequired, request(t, router, http.MethodPut, path, gin.H{"title": "Short Links"}, nil).Code)
+	w = requestWith(t, router, http.MethodPut, path, version, gin.H{"title": "Short Links", "technology_ids": []uint{}}, &updated)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "Short Links", updated.Title)
+	assert.Equal(t, "link-shortener", updated.Slug)
+	assert.Empty(t, updated.Technologies)
+	assert.Equal(t, http.StatusPreconditionFailed, requestWith(t, router, http.MethodDelete, path, version, nil, nil).Code)
+
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodPut, "/admin/projects/999", gin.H{"title": "Nothing"}, nil).Code)
+
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodDelete, path, anyVersion, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/projects/link-shortener", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodDelete, path, nil, nil).Code)
+}
+
+// TestGetProjectsListsPublishedProjects tests that the public listing only
+// holds published projects and applies its filters
+func TestGetProjectsListsPublishedProjects(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	testdb.Project(t, db, author.ID, "draft", models.ProjectStatusDraft)
+	testdb.Project(t, db, author.ID, "easy", models.ProjectStatusPublished)
+	hard := testdb.Project(t, db, author.ID, "hard", models.ProjectStatusPublished)
+	require.NoError(t, db.Model(&hard).Update("difficulty", models.DifficultyExpert).Error)
+	router := projectRouter(db, author)
+
+	var response struct {
+		Projects []slugged `json:"projects"`
+		Total    int64     `json:"total"`
+	}
+	w := request(t, router, http.MethodGet, "/projects", nil, &response)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(2), response.Total)
+	assert.ElementsMatch(t, []string{"easy", "hard"}, slugsOf(response.Projects))
+
+	request(t, router, http.MethodGet, "/projects?difficulty=expert", nil, &response)
+	assert.Equal(t, []string{"hard"}, slugsOf(response.Projects))
+
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/projects/draft", nil, nil).Code)
+}
+
+// TestGetProjectBySlugCountsViews tests that each view of a project is counted
+func TestGetProjectBySlugCountsViews(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	project := testdb.Project(t, db, author.ID, "tool", models.ProjectStatusPublished)
+	router := projectRouter(db, author)
+
+	for i := 1; i <= 2; i++ {
+		var response models.Project
+		require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/projects/tool", nil, &response).Code)
+		assert.Equal(t, i, response.ViewCount)
+	}
+
+	var stored models.Project
+	require.NoError(t, db.First(&stored, project.ID).Error)
+	assert.Equal(t, 2, stored.ViewCount)
+}
@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// revisionRouter serves the post and revision endpoints to an admin
+func revisionRouter(db *gorm.DB, admin models.User) *gin.Engine {
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(admin.ID), "role": string(models.RoleAdmin)})
+	router.POST("/posts", handlers.CreatePost)
+	router.PUT("/posts/:id", handlers.UpdatePost)
+	router.GET("/posts/:id/revisions", handlers.GetPostRevisions)
+	router.GET("/posts/:id/revisions/diff", handlers.DiffPostRevisions)
+	router.GET("/posts/:id/revisions/:version", handlers.GetPostRevision)
+	router.POST("/posts/:id/revisions/:version/restore", handlers.RestorePostRevision)
+	return router
+}
+
+// revisionsResponse is the revision history of a post
+type revisionsResponse struct {
+	Revisions []models.PostRevision `json:"revisions"`
+}
+
+// versions returns the versions of revisions in order
+func versions(revisions []models.PostRevision) []int {
+	list := []int{}
+	for _, revision := range revisions {
+		list = append(list, revision.Version)
+	}
+	return list
+}
+
+// TestPostRevisionHistory tests that content edits are kept as revisions
+// that can be diffed and restored
+func TestPostRevisionHistory(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	editor := testdb.User(t, db, "editor")
+	router := revisionRouter(db, admin)
+
+	var post models.Post
+	w := request(t, router, http.MethodPost, "/posts", gin.H{"title": "First Title", "content": "The first draft of the post.", "status": "draft"}, &post)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	base := fmt.Sprintf("/posts/%d", post.ID)
+
+	// Only title, excerpt and content changes are recorded
+	editorRouter := revisionRouter(db, editor)
+	require.Equal(t, http.StatusOK, requestWith(t, editorRouter, http.MethodPut, base, anyVersion, gin.H{"title": "Second Title"}, nil).Code)
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, base, anyVersion, gin.H{"status": "published"}, nil).Code)
+
+	var history revisionsResponse
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, base+"/revisions", nil, &history).Code)
+	assert.Equal(t, []int{2, 1}, versions(history.Revisions))
+	assert.Equal(t, editor.ID, history.Revisions[0].EditorID)
+	assert.Empty(t, history.Revisions[0].Content)
+
+	var revision models.PostRevision
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, base+"/revisions/1", nil, &revision).Code)
+	assert.Equal(t, "The first draft of the post.", revision.Content)
+
+	var diff struct {
+		Diff string `json:"diff"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, base+"/revisions/diff?from=1&to=2", nil, &diff).Code)
+	assert.Contains(t, diff.Diff, "-Title: First Title\n")
+	assert.Contains(t, diff.Diff, "+Title: Second Title\n")
+	assert.NotContains(t, diff.Diff, "+The first draft")
+
+	assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodGet, base+"/revisions/diff?from=1", nil, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodGet, base+"/revisions/latest", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, base+"/revisions/9", nil, nil).Code)
+
+	// Restoring adds the old state as the newest revision
+	var restored struct {
+		RestoredFrom int         `json:"restored_from"`
+		Post         models.Post `json:"post"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodPost, base+"/revisions/1/restore", nil, &restored).Code)
+	assert.Equal(t, 1, restored.RestoredFrom)
+	assert.Equal(t, "First Title", restored.Post.Title)
+
+	request(t, router, http.MethodGet, base+"/revisions", nil, &history)
+	assert.Equal(t, []int{3, 2, 1}, versions(history.Revisions))
+	require.NotNil(t, history.Revisions[0].RestoredFrom)
+	assert.Equal(t, 1, *history.Revisions[0].RestoredFrom)
+}
+
+// TestPostRevisionBaseline tests that the first edit of a post written
+// before revisions were tracked keeps its previous state
+func TestPostRevisionBaseline(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	post := testdb.Post(t, db, admin.ID, "old-post", models.PostStatusPublished)
+	router := revisionRouter(db, admin)
+
+	base := fmt.Sprintf("/posts/%d", post.ID)
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, base, anyVersion, gin.H{"content": "Rewritten content of the post."}, nil).Code)
+
+	var history revisionsResponse
+	request(t, router, http.MethodGet, base+"/revisions", nil, &history)
+	require.Equal(t, []int{2, 1}, versions(history.Revisions))
+	assert.Equal(t, admin.ID, history.Revisions[1].EditorID)
+
+	var revision models.PostRevision
+	request(t, router, http.MethodGet, base+"/revisions/1", nil, &revision)
+	assert.Equal(t, post.Content, revision.Content)
+}
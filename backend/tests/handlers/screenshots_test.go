@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inUploadsDir runs the test from a directory whose uploads/images holds
+// the named files
+func inUploadsDir(t *testing.T, names ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "uploads", "images"), 0o755))
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "uploads", "images", name), []byte("image"), 0o644))
+	}
+
+	previous, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(previous) })
+}
+
+// screenshotsResponse is the gallery returned by the screenshot endpoints
+type screenshotsResponse struct {
+	Screenshots []models.Screenshot `json:"screenshots"`
+}
+
+// screenshotURLs returns the image URLs of screenshots in order
+func screenshotURLs(screenshots []models.Screenshot) []string {
+	urls := []string{}
+	for _, screenshot := range screenshots {
+		urls = append(urls, screenshot.ImageURL)
+	}
+	return urls
+}
+
+// TestProjectScreenshotGallery tests adding, reordering, captioning and
+// removing the screenshots of a project
+func TestProjectScreenshotGallery(t *testing.T) {
+	inUploadsDir(t, "a.png", "b.png", "c.png")
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	project := testdb.Project(t, db, author.ID, "tool", models.ProjectStatusPublished)
+
+	router := newRouter(db, nil)
+	router.GET("/projects/:slug", handlers.GetProjectBySlug)
+	router.POST("/projects/:id/screenshots", handlers.AddProjectScreenshots)
+	router.PUT("/projects/:id/screenshots/order", handlers.ReorderProjectScreenshots)
+	router.PUT("/screenshots/:id", handlers.UpdateScreenshot)
+	router.DELETE("/screenshots/:id", handlers.DeleteScreenshot)
+	base := fmt.Sprintf("/projects/%d/screenshots", project.ID)
+
+	for _, url := range []string{"https://example.com/a.png", "/uploads/images/missing.png", "/uploads/images/../a.png"} {
+		w := request(t, router, http.MethodPost, base, gin.H{"screenshots": []gin.H{{"image_url": url}}}, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+
+	// New screenshots go to the end of the gallery
+	var added screenshotsResponse
+	w := request(t, router, http.MethodPost, base, gin.H{"screenshots": []gin.H{
+		{"image_url": "/uploads/images/a.png", "title": "Home"},
+		{"image_url": "/uploads/images/b.png"},
+	}}, &added)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	request(t, router, http.MethodPost, base, gin.H{"screenshots": []gin.H{{"image_url": "/uploads/images/c.png"}}}, &added)
+	require.Len(t, added.Screenshots, 1)
+	assert.Equal(t, 2, added.Screenshots[0].Order)
+
+	var gallery []models.Screenshot
+	require.NoError(t, db.Order("id ASC").Find(&gallery).Error)
+	a, b, c := gallery[0].ID, gallery[1].ID, gallery[2].ID
+
+	// A new order has to list every screenshot of the project once
+	for _, ids := range [][]uint{{c, a}, {c, a, a}, {c, a, b, b + 100}} {
+		w := request(t, router, http.MethodPut, base+"/order", gin.H{"screenshot_ids": ids}, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, ids)
+	}
+	var reordered screenshotsResponse
+	w = request(t, router, http.MethodPut, base+"/order", gin.H{"screenshot_ids": []uint{c, a, b}}, &reordered)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"/uploads/images/c.png", "/uploads/images/a.png", "/uploads/images/b.png"}, screenshotURLs(reordered.Screenshots))
+
+	// An empty title clears the caption
+	w = request(t, router, http.MethodPut, fmt.Sprintf("/screenshots/%d", a), gin.H{"title": ""}, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodDelete, fmt.Sprintf("/screenshots/%d", b), nil, nil).Code)
+
+	var shown models.Project
+	request(t, router, http.MethodGet, "/projects/tool", nil, &shown)
+	assert.Equal(t, []string{"/uploads/images/c.png", "/uploads/images/a.png"}, screenshotURLs(shown.Screenshots))
+	assert.Empty(t, shown.Screenshots[1].Title)
+}
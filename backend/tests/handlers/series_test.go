@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seriesRouter serves the series endpoints and single posts
+func seriesRouter(db *gorm.DB) *gin.Engine {
+	router := newRouter(db, nil)
+	router.GET("/posts/:slug", handlers.GetPostBySlug)
+	router.GET("/series/:slug", handlers.GetSeriesBySlug)
+	router.POST("/series", handlers.CreateSeries)
+	router.DELETE("/series/:id", handlers.DeleteSeries)
+	router.POST("/series/:id/posts", handlers.AddSeriesPosts)
+	router.PUT("/series/:id/posts/order", handlers.ReorderSeriesPosts)
+	router.DELETE("/series/:id/posts/:post_id", handlers.RemoveSeriesPost)
+	return router
+}
+
+// seriesResponse is a series returned by the management endpoints
+type seriesResponse struct {
+	Series struct {
+		ID    uint      `json:"id"`
+		Posts []slugged `json:"posts"`
+	} `json:"series"`
+}
+
+// createSeries creates a series through the API and returns its id
+func createSeries(t *testing.T, router *gin.Engine, title string) uint {
+	t.Helper()
+	var created seriesResponse
+	w := request(t, router, http.MethodPost, "/series", gin.H{"title": title}, &created)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return created.Series.ID
+}
+
+// postNavigation returns the series navigation shown with a post
+func postNavigation(t *testing.T, router *gin.Engine, slug string) *handlers.SeriesNavigation {
+	t.Helper()
+	var detail struct {
+		Series *handlers.SeriesNavigation `json:"series"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/posts/"+slug, nil, &detail).Code)
+	return detail.Series
+}
+
+// TestSeriesNavigation tests that posts are kept in series order and that
+// readers only navigate between published parts
+func TestSeriesNavigation(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	one := testdb.Post(t, db, author.ID, "one", models.PostStatusPublished)
+	two := testdb.Post(t, db, author.ID, "two", models.PostStatusDraft)
+	three := testdb.Post(t, db, author.ID, "three", models.PostStatusPublished)
+	router := seriesRouter(db)
+
+	id := createSeries(t, router, "Go Basics")
+	base := fmt.Sprintf("/series/%d/posts", id)
+	var response seriesResponse
+	w := request(t, router, http.MethodPost, base, gin.H{"post_ids": []uint{one.ID, two.ID, three.ID}}, &response)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"one", "two", "three"}, slugsOf(response.Series.Posts))
+
+	assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodPut, base+"/order", gin.H{"post_ids": []uint{three.ID, one.ID}}, nil).Code)
+	request(t, router, http.MethodPut, base+"/order", gin.H{"post_ids": []uint{three.ID, two.ID, one.ID}}, &response)
+	assert.Equal(t, []string{"three", "two", "one"}, slugsOf(response.Series.Posts))
+
+	// The draft is skipped by readers
+	nav := postNavigation(t, router, "one")
+	require.NotNil(t, nav)
+	assert.Equal(t, 2, nav.Position)
+	assert.Equal(t, 2, nav.Total)
+	require.NotNil(t, nav.Previous)
+	assert.Equal(t, "three", nav.Previous.Slug)
+	assert.Nil(t, nav.Next)
+
+	var shown struct {
+		PostCount int64     `json:"post_count"`
+		Posts     []slugged `json:"posts"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/series/go-basics", nil, &shown).Code)
+	assert.Equal(t, []string{"three", "one"}, slugsOf(shown.Posts))
+	assert.Equal(t, int64(2), shown.PostCount)
+
+	// Removing a part closes the gap it leaves
+	request(t, router, http.MethodDelete, fmt.Sprintf("%s/%d", base, three.ID), nil, &response)
+	assert.Equal(t, []string{"two", "one"}, slugsOf(response.Series.Posts))
+	var stored models.Post
+	require.NoError(t, db.First(&stored, one.ID).Error)
+	assert.Equal(t, 2, stored.SeriesPosition)
+	assert.Nil(t, postNavigation(t, router, "three"))
+}
+
+// TestSeriesPostBelongsToOneSeries tests that a post cannot join a second
+// series and is detached when its series is deleted
+func TestSeriesPostBelongsToOneSeries(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	router := seriesRouter(db)
+
+	first := createSeries(t, router, "First Series")
+	second := createSeries(t, router, "Second Series")
+	add := gin.H{"post_ids": []uint{post.ID}}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodPost, fmt.Sprintf("/series/%d/posts", first), add, nil).Code)
+	assert.Equal(t, http.StatusOK, request(t, router, http.MethodPost, fmt.Sprintf("/series/%d/posts", first), add, nil).Code)
+	assert.Equal(t, http.StatusConflict, request(t, router, http.MethodPost, fmt.Sprintf("/series/%d/posts", second), add, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodPost, fmt.Sprintf("/series/%d/posts", second), gin.H{"post_ids": []uint{999}}, nil).Code)
+
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodDelete, fmt.Sprintf("/series/%d", first), nil, nil).Code)
+	var stored models.Post
+	require.NoError(t, db.First(&stored, post.ID).Error)
+	assert.Nil(t, stored.SeriesID)
+	assert.Equal(t, http.StatusOK, request(t, router, http.MethodPost, fmt.Sprintf("/series/%d/posts", second), add, nil).Code)
+}
@@ -0,0 +1,99 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// slugRouter serves posts, tags and categories by slug along with their
+// admin updates
+func slugRouter(db *gorm.DB, admin models.User) *gin.Engine {
+	router := newRouter(db, gin.H{"user_id": fmt.Sprint(admin.ID), "role": string(models.RoleAdmin)})
+	router.GET("/posts/:slug", handlers.GetPostBySlug)
+	router.PUT("/posts/:id", handlers.UpdatePost)
+	router.GET("/tags/:slug", handlers.GetTagBySlug)
+	router.PUT("/tags/:id", handlers.UpdateTag)
+	router.GET("/categories/:slug", handlers.GetCategoryBySlug)
+	router.PUT("/categories/:id", handlers.UpdateCategory)
+	return router
+}
+
+// redirectResponse is the body of a slug redirect
+type redirectResponse struct {
+	Redirect struct {
+		Type     string `json:"type"`
+		Slug     string `json:"slug"`
+		Location string `json:"location"`
+	} `json:"redirect"`
+}
+
+// TestRenamedPostRedirects tests that old post slugs permanently redirect
+// to the current one until they are taken back into use
+func TestRenamedPostRedirects(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	post := testdb.Post(t, db, admin.ID, "first", models.PostStatusPublished)
+	other := testdb.Post(t, db, admin.ID, "other", models.PostStatusPublished)
+	router := slugRouter(db, admin)
+	path := fmt.Sprintf("/posts/%d", post.ID)
+
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, path, anyVersion, gin.H{"slug": "second"}, nil).Code)
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, path, anyVersion, gin.H{"slug": "third"}, nil).Code)
+	assert.Equal(t, http.StatusConflict, requestWith(t, router, http.MethodPut, path, anyVersion, gin.H{"slug": "other"}, nil).Code)
+
+	// Every old slug leads straight to the current one, keeping the query
+	for _, slug := range []string{"first", "second"} {
+		var moved redirectResponse
+		w := request(t, router, http.MethodGet, "/posts/"+slug+"?render=html", nil, &moved)
+		require.Equal(t, http.StatusMovedPermanently, w.Code, slug)
+		assert.Equal(t, "/api/v1/posts/third?render=html", w.Header().Get("Location"))
+		assert.Equal(t, "post", moved.Redirect.Type)
+		assert.Equal(t, "third", moved.Redirect.Slug)
+	}
+	assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/posts/third", nil, nil).Code)
+
+	// An old slug taken by another post serves that post
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, fmt.Sprintf("/posts/%d", other.ID), anyVersion, gin.H{"slug": "first"}, nil).Code)
+	var shown slugged
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/posts/first", nil, &shown).Code)
+	assert.Equal(t, "first", shown.Slug)
+	assert.Equal(t, http.StatusMovedPermanently, request(t, router, http.MethodGet, "/posts/other", nil, nil).Code)
+
+	// Unpublished posts are not redirected to
+	require.NoError(t, db.Model(&post).Update("status", models.PostStatusDraft).Error)
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/posts/second", nil, nil).Code)
+}
+
+// TestRenamedTaxonomyRedirects tests that renaming a tag or category
+// redirects its old slug
+func TestRenamedTaxonomyRedirects(t *testing.T) {
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	tag := models.Tag{Name: "Golang", Slug: "golang"}
+	require.NoError(t, db.Create(&tag).Error)
+	category := models.Category{Name: "Guides", Slug: "guides"}
+	require.NoError(t, db.Create(&category).Error)
+	router := slugRouter(db, admin)
+
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, fmt.Sprintf("/tags/%d", tag.ID), anyVersion, gin.H{"name": "Go"}, nil).Code)
+	w := request(t, router, http.MethodGet, "/tags/golang", nil, nil)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/api/v1/tags/go", w.Header().Get("Location"))
+
+	require.Equal(t, http.StatusOK, requestWith(t, router, http.MethodPut, fmt.Sprintf("/categories/%d", category.ID), anyVersion, gin.H{"name": "How To"}, nil).Code)
+	w = request(t, router, http.MethodGet, "/categories/guides", nil, nil)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/api/v1/categories/how-to", w.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusNotFound, request(t, router, http.MethodGet, "/tags/unknown", nil, nil).Code)
+}
@@ -0,0 +1,124 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createTechnologies creates technologies named after their slugs
+func createTechnologies(t *testing.T, db *gorm.DB, slugs ...string) map[string]models.Technology {
+	t.Helper()
+	technologies := make(map[string]models.Technology, len(slugs))
+	for _, slug := range slugs {
+		technology := models.Technology{Name: slug, Slug: slug}
+		require.NoError(t, db.Create(&technology).Error)
+		technologies[slug] = technology
+	}
+	return technologies
+}
+
+// stackProject creates a published project of a difficulty using technologies
+func stackProject(t *testing.T, db *gorm.DB, authorID uint, slug string, difficulty models.ProjectDifficulty, technologies ...models.Technology) models.Project {
+	t.Helper()
+	project := testdb.Project(t, db, authorID, slug, models.ProjectStatusPublished)
+	require.NoError(t, db.Model(&project).Update("difficulty", difficulty).Error)
+	for _, technology := range technologies {
+		require.NoError(t, db.Exec("INSERT INTO project_technologies (project_id, technology_id) VALUES (?, ?)", project.ID, technology.ID).Error)
+	}
+	return project
+}
+
+// TestGetTechRadar tests that the radar counts the published projects using
+// each technology by difficulty, most used first
+func TestGetTechRadar(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	tech := createTechnologies(t, db, "go", "react", "rust", "vue")
+
+	stackProject(t, db, author.ID, "api", models.DifficultyAdvanced, tech["go"], tech["react"])
+	stackProject(t, db, author.ID, "cli", models.DifficultyBeginner, tech["go"])
+	stackProject(t, db, author.ID, "site", models.DifficultyBeginner, tech["vue"])
+	draft := stackProject(t, db, author.ID, "draft", models.DifficultyExpert, tech["go"], tech["rust"])
+	require.NoError(t, db.Model(&draft).Update("status", models.ProjectStatusDraft).Error)
+	deleted := stackProject(t, db, author.ID, "deleted", models.DifficultyExpert, tech["vue"])
+	require.NoError(t, db.Model(&deleted).Update("deleted_at", time.Now()).Error)
+
+	router := newRouter(db, nil)
+	router.GET("/technologies/radar", handlers.GetTechRadar)
+
+	var response struct {
+		Radar []struct {
+			Technology   models.Technology                  `json:"technology"`
+			ProjectCount int64                              `json:"project_count"`
+			ByDifficulty map[models.ProjectDifficulty]int64 `json:"by_difficulty"`
+		} `json:"radar"`
+	}
+	require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/technologies/radar", nil, &response).Code)
+
+	var order []string
+	counts := map[string]int64{}
+	for _, entry := range response.Radar {
+		order = append(order, entry.Technology.Slug)
+		counts[entry.Technology.Slug] = entry.ProjectCount
+		assert.Len(t, entry.ByDifficulty, 4)
+	}
+	assert.Equal(t, []string{"go", "react", "vue", "rust"}, order)
+	assert.Equal(t, map[string]int64{"go": 2, "react": 1, "vue": 1, "rust": 0}, counts)
+	assert.Equal(t, int64(1), response.Radar[0].ByDifficulty[models.DifficultyAdvanced])
+	assert.Equal(t, int64(1), response.Radar[0].ByDifficulty[models.DifficultyBeginner])
+	assert.Zero(t, response.Radar[0].ByDifficulty[models.DifficultyExpert])
+}
+
+// TestGetProjectsFiltersByStack tests that projects must use every
+// requested technology, given repeated or comma-separated
+func TestGetProjectsFiltersByStack(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	tech := createTechnologies(t, db, "go", "react")
+	stackProject(t, db, author.ID, "api", models.DifficultyAdvanced, tech["go"], tech["react"])
+	stackProject(t, db, author.ID, "cli", models.DifficultyBeginner, tech["go"])
+
+	router := newRouter(db, nil)
+	router.GET("/projects", handlers.GetProjects)
+
+	for query, expected := range map[string][]string{
+		"technology=go":                  {"api", "cli"},
+		"technology=go,react":            {"api"},
+		"technology=go&technology=react": {"api"},
+		"technology=react,unknown":       {},
+	} {
+		var response struct {
+			Projects []slugged `json:"projects"`
+			Total    int64     `json:"total"`
+		}
+		require.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/projects?"+query, nil, &response).Code, query)
+		assert.ElementsMatch(t, expected, slugsOf(response.Projects), query)
+		assert.Equal(t, int64(len(expected)), response.Total, query)
+	}
+}
+
+// TestDeleteTechnologyInUse tests that technologies used by a project are kept
+func TestDeleteTechnologyInUse(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	tech := createTechnologies(t, db, "go", "elm")
+	stackProject(t, db, author.ID, "cli", models.DifficultyBeginner, tech["go"])
+
+	router := newRouter(db, nil)
+	router.DELETE("/technologies/:id", handlers.DeleteTechnology)
+
+	w := request(t, router, http.MethodDelete, fmt.Sprintf("/technologies/%d", tech["go"].ID), nil, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = request(t, router, http.MethodDelete, fmt.Sprintf("/technologies/%d", tech["elm"].ID), nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/handlers"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchIgnoresStatusForVisitors tests that only admins can search
+// content that is not published
+func TestSearchIgnoresStatusForVisitors(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	testdb.Post(t, db, author.ID, "published", models.PostStatusPublished)
+	testdb.Post(t, db, author.ID, "draft", models.PostStatusDraft)
+	testdb.Post(t, db, author.ID, "archived", models.PostStatusArchived)
+
+	search := func(set gin.H, status string) []string {
+		router := newRouter(db, set)
+		router.GET("/search", handlers.Search)
+		var response struct {
+			Results []slugged `json:"results"`
+		}
+		w := request(t, router, http.MethodGet, "/search?type=posts&status="+status, nil, &response)
+		require.Equal(t, http.StatusOK, w.Code)
+		return slugsOf(response.Results)
+	}
+
+	assert.Equal(t, []string{"published"}, search(nil, "draft"))
+	assert.Equal(t, []string{"published"}, search(gin.H{"role": string(models.RoleUser)}, "archived"))
+	assert.Equal(t, []string{"draft"}, search(gin.H{"role": string(models.RoleAdmin)}, "draft"))
+}
+
+// TestTaxonomyListsPublishedPosts tests that categories and tags only list
+// their published posts
+func TestTaxonomyListsPublishedPosts(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	category := models.Category{Name: "Go", Slug: "go"}
+	require.NoError(t, db.Create(&category).Error)
+	tag := models.Tag{Name: "Go", Slug: "go"}
+	require.NoError(t, db.Create(&tag).Error)
+
+	for _, status := range []models.PostStatus{models.PostStatusPublished, models.PostStatusDraft, models.PostStatusArchived} {
+		post := testdb.Post(t, db, author.ID, string(status), status)
+		require.NoError(t, db.Exec("INSERT INTO post_categories (post_id, category_id) VALUES (?, ?)", post.ID, category.ID).Error)
+		require.NoError(t, db.Exec("INSERT INTO post_tags (post_id, tag_id) VALUES (?, ?)", post.ID, tag.ID).Error)
+	}
+
+	router := newRouter(db, nil)
+	router.GET("/categories/:slug", handlers.GetCategoryBySlug)
+	router.GET("/tags/:slug", handlers.GetTagBySlug)
+
+	for _, path := range []string{"/categories/go", "/tags/go"} {
+		var response struct {
+			Posts []slugged `json:"posts"`
+		}
+		w := request(t, router, http.MethodGet, path, nil, &response)
+		require.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, []string{"published"}, slugsOf(response.Posts), path)
+	}
+}
+
+// TestGetPostBySlugFlagsExpiredPosts tests that a published post past its
+// expiry time is flagged as archived before the archiver has run
+func TestGetPostBySlugFlagsExpiredPosts(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	expired := testdb.Post(t, db, author.ID, "expired", models.PostStatusPublished)
+	require.NoError(t, db.Model(&expired).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	running := testdb.Post(t, db, author.ID, "running", models.PostStatusPublished)
+	require.NoError(t, db.Model(&running).Update("expires_at", time.Now().Add(time.Hour)).Error)
+
+	router := newRouter(db, nil)
+	router.GET("/posts/:slug", handlers.GetPostBySlug)
+
+	for slug, archived := range map[string]bool{"expired": true, "running": false} {
+		var response struct {
+			Archived bool `json:"archived"`
+		}
+		w := request(t, router, http.MethodGet, "/posts/"+slug, nil, &response)
+		require.Equal(t, http.StatusOK, w.Code, slug)
+		assert.Equal(t, archived, response.Archived, slug)
+	}
+}
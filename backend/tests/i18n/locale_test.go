@@ -0,0 +1,39 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"codewithdell/backend/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var supported = []string{"en", "id", "pt-BR"}
+
+// TestNegotiatePrefersLangParameter tests that an explicit lang parameter wins over the header
+func TestNegotiatePrefersLangParameter(t *testing.T) {
+	assert.Equal(t, "id", i18n.Negotiate("id", "en-US,en;q=0.9", supported, "en"))
+	assert.Equal(t, "en", i18n.Negotiate("fr", "en-US,en;q=0.9", supported, "id"))
+}
+
+// TestNegotiateUsesAcceptLanguageQuality tests that header entries are tried by quality value
+func TestNegotiateUsesAcceptLanguageQuality(t *testing.T) {
+	assert.Equal(t, "id", i18n.Negotiate("", "fr;q=0.9, id;q=0.8, en;q=0.7", supported, "en"))
+	assert.Equal(t, "pt-BR", i18n.Negotiate("", "en;q=0.5, pt-br", supported, "en"))
+	assert.Equal(t, "en", i18n.Negotiate("", "id;q=0, *", supported, "en"))
+	assert.Equal(t, "en", i18n.Negotiate("", "", supported, "en"))
+}
+
+// TestMatchFallsBackToBaseLanguage tests regional tags and separators
+func TestMatchFallsBackToBaseLanguage(t *testing.T) {
+	locale, ok := i18n.Match("id-ID", supported)
+	assert.True(t, ok)
+	assert.Equal(t, "id", locale)
+
+	locale, ok = i18n.Match("PT_br", supported)
+	assert.True(t, ok)
+	assert.Equal(t, "pt-BR", locale)
+
+	_, ok = i18n.Match("pt-PT", supported)
+	assert.False(t, ok)
+}
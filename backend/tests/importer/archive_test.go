@@ -0,0 +1,56 @@
+package importer_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"codewithdell/backend/internal/importer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunRejectsNewerExport tests that exports from a newer version are not restored
+func TestRunRejectsNewerExport(t *testing.T) {
+	fsys := fstest.MapFS{
+		importer.ManifestFile: {Data: []byte(`{"format": "codewithdell-export", "version": 99}`)},
+		"posts/hello.md":      {Data: []byte("---\ntitle: Hello\n---\n\nBody\n")},
+	}
+
+	report, err := importer.New(nil, importer.Options{DryRun: true}).Run(fsys)
+	require.Error(t, err)
+	assert.Nil(t, report)
+	assert.Contains(t, err.Error(), "newer than the supported version")
+}
+
+// TestParseExportedFrontMatter tests that the front matter written by the
+// exporter reads back into the same fields
+func TestParseExportedFrontMatter(t *testing.T) {
+	doc, err := importer.Parse([]byte(`---
+title: 'Go: Generics'
+slug: go-generics
+status: archived
+date: 2023-05-06T07:08:09Z
+summary: Type parameters in practice.
+image: /uploads/images/generics.png
+tags:
+  - Go
+categories:
+  - Backend
+---
+
+# Generics
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Go: Generics", doc.Title)
+	assert.Equal(t, "go-generics", doc.Slug)
+	assert.Equal(t, "archived", doc.Status)
+	assert.Equal(t, "Type parameters in practice.", doc.Summary)
+	assert.Equal(t, "/uploads/images/generics.png", doc.Image)
+	assert.Equal(t, []string{"Go"}, doc.Tags)
+	assert.Equal(t, []string{"Backend"}, doc.Categories)
+	assert.Equal(t, "# Generics\n", doc.Body)
+	require.NotNil(t, doc.Date)
+	assert.Equal(t, 2023, doc.Date.Year())
+}
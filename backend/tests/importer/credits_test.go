@@ -0,0 +1,125 @@
+package importer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/exporter"
+	"codewithdell/backend/internal/importer"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// exportSite exports an expiring post by alice, reviewed by bob, and a
+// project by bob
+func exportSite(t *testing.T) *zip.Reader {
+	t.Helper()
+	db := testdb.Open(t)
+	alice := testdb.User(t, db, "alice")
+	bob := testdb.User(t, db, "bob")
+
+	post := models.Post{Title: "Hello", Slug: "hello", Content: "Hello world, this is a post.", Status: models.PostStatusDraft, AuthorID: alice.ID, ExpiresAt: &exportedExpiry}
+	require.NoError(t, db.Omit(clause.Associations).Create(&post).Error)
+	project := models.Project{Title: "Tool", Slug: "tool", Content: "A tool.", Status: models.ProjectStatusDraft, AuthorID: bob.ID}
+	require.NoError(t, db.Omit(clause.Associations).Create(&project).Error)
+	require.NoError(t, db.Create(&[]models.Contributor{
+		{UserID: alice.ID, PostID: &post.ID, Role: models.ContributorRoleAuthor, Position: 0},
+		{UserID: bob.ID, PostID: &post.ID, Role: models.ContributorRoleReviewer, Position: 1},
+		{UserID: bob.ID, ProjectID: &project.ID, Role: models.ContributorRoleAuthor, Position: 0},
+	}).Error)
+
+	var buf bytes.Buffer
+	require.NoError(t, exporter.New(db, t.TempDir()).Write(context.Background(), &buf))
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return archive
+}
+
+// exportedExpiry is the expiry time of the exported post
+var exportedExpiry = time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// credited returns the ordered usernames and roles credited on a post or project
+func credited(t *testing.T, db *gorm.DB, column string, id uint) []string {
+	t.Helper()
+	var contributors []models.Contributor
+	require.NoError(t, db.Preload("User").Where(column+" = ?", id).
+		Order("position ASC").Find(&contributors).Error)
+	var names []string
+	for _, contributor := range contributors {
+		names = append(names, contributor.User.Username+":"+string(contributor.Role))
+	}
+	return names
+}
+
+// TestRestoreCreditsExportedUsers tests that restored posts and projects are
+// credited to the users of the export, matched by email
+func TestRestoreCreditsExportedUsers(t *testing.T) {
+	archive := exportSite(t)
+
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+	bob := testdb.User(t, db, "bob")
+	alice := testdb.User(t, db, "alice")
+
+	report, err := importer.New(db, importer.Options{AuthorID: admin.ID, UploadDir: t.TempDir()}).Run(archive)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+
+	var post models.Post
+	require.NoError(t, db.Where("slug = ?", "hello").First(&post).Error)
+	assert.Equal(t, alice.ID, post.AuthorID)
+	assert.Equal(t, []string{"alice:author", "bob:reviewer"}, credited(t, db, "post_id", post.ID))
+
+	var project models.Project
+	require.NoError(t, db.Where("slug = ?", "tool").First(&project).Error)
+	assert.Equal(t, bob.ID, project.AuthorID)
+	assert.Equal(t, []string{"bob:author"}, credited(t, db, "project_id", project.ID))
+
+	// Restoring again changes nothing
+	report, err = importer.New(db, importer.Options{AuthorID: admin.ID, UploadDir: t.TempDir()}).Run(archive)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Unchanged)
+}
+
+// TestRestoreFallsBackToImportingUser tests that content by users without an
+// account is credited to the importing user
+func TestRestoreFallsBackToImportingUser(t *testing.T) {
+	archive := exportSite(t)
+
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+
+	report, err := importer.New(db, importer.Options{AuthorID: admin.ID, UploadDir: t.TempDir()}).Run(archive)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Created)
+	assert.Contains(t, report.Items[0].Warnings, `author "alice@example.com" has no account, the importing user is credited instead`)
+
+	var post models.Post
+	require.NoError(t, db.Where("slug = ?", "hello").First(&post).Error)
+	assert.Equal(t, admin.ID, post.AuthorID)
+	assert.Equal(t, []string{"admin:author"}, credited(t, db, "post_id", post.ID))
+}
+
+// TestRestoreKeepsExpiry tests that restored posts keep their expiry time
+func TestRestoreKeepsExpiry(t *testing.T) {
+	archive := exportSite(t)
+
+	db := testdb.Open(t)
+	admin := testdb.User(t, db, "admin")
+
+	_, err := importer.New(db, importer.Options{AuthorID: admin.ID, UploadDir: t.TempDir()}).Run(archive)
+	require.NoError(t, err)
+
+	var post models.Post
+	require.NoError(t, db.Where("slug = ?", "hello").First(&post).Error)
+	require.NotNil(t, post.ExpiresAt)
+	assert.True(t, post.ExpiresAt.Equal(exportedExpiry))
+}
@@ -0,0 +1,95 @@
+package importer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/importer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseYAMLFrontMatter tests a Jekyll post with YAML front matter
+func TestParseYAMLFrontMatter(t *testing.T) {
+	doc, err := importer.Parse([]byte(`---
+title: "Building REST APIs in Go"
+date: 2021-03-04 10:30:00 +0700
+categories: backend go
+tags: [Go, REST, go]
+published: false
+excerpt: Routing, middleware and testing.
+---
+
+Body text.
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Building REST APIs in Go", doc.Title)
+	assert.Equal(t, []string{"backend", "go"}, doc.Categories)
+	assert.Equal(t, []string{"Go", "REST"}, doc.Tags)
+	assert.True(t, doc.Draft)
+	assert.Equal(t, "Routing, middleware and testing.", doc.Summary)
+	assert.Equal(t, "Body text.\n", doc.Body)
+	require.NotNil(t, doc.Date)
+	assert.True(t, doc.Date.Equal(time.Date(2021, 3, 4, 3, 30, 0, 0, time.UTC)))
+}
+
+// TestParseTOMLFrontMatter tests a Hugo page with TOML front matter
+func TestParseTOMLFrontMatter(t *testing.T) {
+	doc, err := importer.Parse([]byte("+++\r\ntitle = \"Hello Hugo\"\r\nslug = \"hello\"\r\ndate = 2020-01-02\r\nexpiryDate = 2021-01-02\r\ndraft = true\r\ncategories = [\"Notes\"]\r\n[cover]\r\nimage = \"cover.png\"\r\n+++\r\n# Hello\r\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello Hugo", doc.Title)
+	assert.Equal(t, "hello", doc.Slug)
+	assert.True(t, doc.Draft)
+	assert.Equal(t, []string{"Notes"}, doc.Categories)
+	assert.Equal(t, "cover.png", doc.Image)
+	assert.Equal(t, "# Hello\n", doc.Body)
+	require.NotNil(t, doc.Date)
+	assert.Equal(t, "2020-01-02", doc.Date.Format("2006-01-02"))
+	require.NotNil(t, doc.Expires)
+	assert.Equal(t, "2021-01-02", doc.Expires.Format("2006-01-02"))
+}
+
+// TestParseRejectsInvalidFrontMatter tests unclosed front matter and bad dates
+func TestParseRejectsInvalidFrontMatter(t *testing.T) {
+	_, err := importer.Parse([]byte("---\ntitle: Unclosed\n"))
+	assert.Error(t, err)
+
+	_, err = importer.Parse([]byte("---\ntitle: Bad date\ndate: someday\n---\n"))
+	assert.Error(t, err)
+
+	doc, err := importer.Parse([]byte("# Just Markdown\n"))
+	require.NoError(t, err)
+	assert.Empty(t, doc.Title)
+	assert.Equal(t, "# Just Markdown\n", doc.Body)
+}
+
+// TestOpenZipUsesSingleTopLevelDirectory tests that a wrapping directory is treated as the root
+func TestOpenZipUsesSingleTopLevelDirectory(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range []string{"blog/content/post.md", "blog/static/images/a.png"} {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte("x"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	fsys, err := importer.OpenZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	_, err = fs.Stat(fsys, "static/images/a.png")
+	assert.NoError(t, err)
+}
+
+// TestSlugify tests slugs derived from titles and file names
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "hello-world", importer.Slugify("Hello, World!"))
+	assert.Equal(t, "my-first-post", importer.Slugify(" my_first  post "))
+}
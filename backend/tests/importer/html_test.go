@@ -0,0 +1,44 @@
+package importer_test
+
+import (
+	"testing"
+
+	"codewithdell/backend/internal/importer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHTMLToMarkdownClassicEditor tests that blank lines of classic editor
+// content become paragraphs and single line breaks become hard breaks
+func TestHTMLToMarkdownClassicEditor(t *testing.T) {
+	markdown := importer.HTMLToMarkdown("Hello <strong>world </strong>and *stars*.\n\nFirst line\nsecond line\n\n<pre class=\"language-go\">func main() {\n\n}</pre>")
+
+	assert.Equal(t, "Hello **world** and \\*stars\\*.\n\nFirst line\\\nsecond line\n\n```go\nfunc main() {\n\n}\n```\n", markdown)
+}
+
+// TestHTMLToMarkdownBlockEditor tests block editor content
+func TestHTMLToMarkdownBlockEditor(t *testing.T) {
+	markdown := importer.HTMLToMarkdown(`<!-- wp:heading -->
+<h2>Setup</h2>
+<!-- /wp:heading -->
+<!-- wp:paragraph -->
+<p>Run <code>go test</code> and read <a href="https://go.dev/doc">the docs</a>.</p>
+<!-- /wp:paragraph -->
+<!-- wp:list -->
+<ul><li>One</li><li>Two<ul><li>Nested</li></ul></li></ul>
+<!-- /wp:list -->
+<!-- wp:quote -->
+<blockquote><p>Quoted</p><p>Twice</p></blockquote>
+<!-- /wp:quote -->`)
+
+	assert.Equal(t, "## Setup\n\nRun `go test` and read [the docs](https://go.dev/doc).\n\n- One\n- Two\n  - Nested\n\n> Quoted\n>\n> Twice\n", markdown)
+}
+
+// TestHTMLToMarkdownCaptionAndTable tests the caption shortcode and tables
+func TestHTMLToMarkdownCaptionAndTable(t *testing.T) {
+	markdown := importer.HTMLToMarkdown(`[caption id="attachment_7" align="aligncenter"]<img src="/uploads/wordpress/2021/03/chart.png" alt="Chart"> Requests per second[/caption]
+
+<table><tr><th>Router</th><th>Allocs</th></tr><tr><td>gin|chi</td><td>0</td></tr></table>`)
+
+	assert.Equal(t, "![Chart](/uploads/wordpress/2021/03/chart.png)\n\n*Requests per second*\n\n| Router | Allocs |\n| --- | --- |\n| gin\\|chi | 0 |\n", markdown)
+}
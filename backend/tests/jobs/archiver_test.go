@@ -0,0 +1,101 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/jobs"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setPostTimes sets the publish and expiry times of a post
+func setPostTimes(t *testing.T, db *gorm.DB, id uint, publishedAt, expiresAt *time.Time) {
+	t.Helper()
+	require.NoError(t, db.Model(&models.Post{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": publishedAt, "expires_at": expiresAt}).Error)
+}
+
+// postStatus returns the status of a post
+func postStatus(t *testing.T, db *gorm.DB, id uint) models.PostStatus {
+	t.Helper()
+	var post models.Post
+	require.NoError(t, db.First(&post, id).Error)
+	return post.Status
+}
+
+// TestArchiverArchivesExpiredPosts tests that published posts past their
+// expiry time are archived with an audit entry and others are left alone
+func TestArchiverArchivesExpiredPosts(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	expired := testdb.Post(t, db, author.ID, "expired", models.PostStatusPublished)
+	setPostTimes(t, db, expired.ID, &now, &past)
+	running := testdb.Post(t, db, author.ID, "running", models.PostStatusPublished)
+	setPostTimes(t, db, running.ID, &now, &future)
+	draft := testdb.Post(t, db, author.ID, "draft", models.PostStatusDraft)
+	setPostTimes(t, db, draft.ID, nil, &past)
+
+	require.NoError(t, jobs.NewArchiver(db).Run(context.Background()))
+
+	assert.Equal(t, models.PostStatusArchived, postStatus(t, db, expired.ID))
+	assert.Equal(t, models.PostStatusPublished, postStatus(t, db, running.ID))
+	assert.Equal(t, models.PostStatusDraft, postStatus(t, db, draft.ID))
+
+	var entries []models.AuditEntry
+	require.NoError(t, db.Find(&entries).Error)
+	require.Len(t, entries, 1)
+	assert.Equal(t, expired.ID, entries[0].EntityID)
+	assert.Equal(t, models.AuditActionArchive, entries[0].Action)
+	assert.Equal(t, "expired", entries[0].Reason)
+
+	// Archived posts are not archived again
+	require.NoError(t, jobs.NewArchiver(db).Run(context.Background()))
+	var count int64
+	require.NoError(t, db.Model(&models.AuditEntry{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestArchiverAppliesRules tests that enabled rules archive posts published
+// before their cutoff, limited to their category when they have one
+func TestArchiverAppliesRules(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	category := models.Category{Name: "News", Slug: "news"}
+	require.NoError(t, db.Create(&category).Error)
+	old, recent := time.Now().AddDate(-2, 0, 0), time.Now().AddDate(0, -6, 0)
+
+	oldNews := testdb.Post(t, db, author.ID, "old-news", models.PostStatusPublished)
+	setPostTimes(t, db, oldNews.ID, &old, nil)
+	recentNews := testdb.Post(t, db, author.ID, "recent-news", models.PostStatusPublished)
+	setPostTimes(t, db, recentNews.ID, &recent, nil)
+	oldGuide := testdb.Post(t, db, author.ID, "old-guide", models.PostStatusPublished)
+	setPostTimes(t, db, oldGuide.ID, &old, nil)
+	for _, post := range []models.Post{oldNews, recentNews} {
+		require.NoError(t, db.Exec("INSERT INTO post_categories (post_id, category_id) VALUES (?, ?)", post.ID, category.ID).Error)
+	}
+
+	rules := []models.ArchiveRule{
+		{Name: "Old news", CategoryID: &category.ID, MaxAgeMonths: 12, Enabled: true},
+		{Name: "Disabled", MaxAgeMonths: 1, Enabled: false},
+	}
+	require.NoError(t, db.Create(&rules).Error)
+
+	require.NoError(t, jobs.NewArchiver(db).Run(context.Background()))
+
+	assert.Equal(t, models.PostStatusArchived, postStatus(t, db, oldNews.ID))
+	assert.Equal(t, models.PostStatusPublished, postStatus(t, db, recentNews.ID))
+	assert.Equal(t, models.PostStatusPublished, postStatus(t, db, oldGuide.ID))
+
+	var entry models.AuditEntry
+	require.NoError(t, db.Where("entity_id = ?", oldNews.ID).First(&entry).Error)
+	assert.Equal(t, "rule: Old news", entry.Reason)
+}
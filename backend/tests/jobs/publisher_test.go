@@ -0,0 +1,51 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/jobs"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPublisherPublishesDueContent tests that scheduled posts and projects
+// are published once their publish time has passed, keeping that time, and
+// that content scheduled for later is left alone
+func TestPublisherPublishesDueContent(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	past, future := time.Now().Add(-time.Hour).Truncate(time.Second), time.Now().Add(time.Hour)
+
+	duePost := testdb.Post(t, db, author.ID, "due", models.PostStatusScheduled)
+	setPostTimes(t, db, duePost.ID, &past, nil)
+	laterPost := testdb.Post(t, db, author.ID, "later", models.PostStatusScheduled)
+	setPostTimes(t, db, laterPost.ID, &future, nil)
+	dueProject := testdb.Project(t, db, author.ID, "due", models.ProjectStatusScheduled)
+	require.NoError(t, db.Model(&dueProject).Update("published_at", past).Error)
+	laterProject := testdb.Project(t, db, author.ID, "later", models.ProjectStatusScheduled)
+	require.NoError(t, db.Model(&laterProject).Update("published_at", future).Error)
+
+	require.NoError(t, jobs.NewPublisher(db).Run(context.Background()))
+
+	var post models.Post
+	require.NoError(t, db.First(&post, duePost.ID).Error)
+	assert.Equal(t, models.PostStatusPublished, post.Status)
+	require.NotNil(t, post.PublishedAt)
+	assert.True(t, past.Equal(*post.PublishedAt))
+	assert.Equal(t, duePost.Version+1, post.Version)
+	assert.Equal(t, models.PostStatusScheduled, postStatus(t, db, laterPost.ID))
+
+	var project models.Project
+	require.NoError(t, db.First(&project, dueProject.ID).Error)
+	assert.Equal(t, models.ProjectStatusPublished, project.Status)
+	require.NotNil(t, project.PublishedAt)
+	assert.True(t, past.Equal(*project.PublishedAt))
+	var later models.Project
+	require.NoError(t, db.First(&later, laterProject.ID).Error)
+	assert.Equal(t, models.ProjectStatusScheduled, later.Status)
+}
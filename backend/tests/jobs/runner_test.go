@@ -0,0 +1,55 @@
+package jobs_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/jobs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunnerRunsJobUntilStopped tests that jobs run on start and on every tick
+func TestRunnerRunsJobUntilStopped(t *testing.T) {
+	var runs int32
+	runner := jobs.NewRunner()
+	runner.Add("counter", 10*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	runner.Start()
+	time.Sleep(55 * time.Millisecond)
+	runner.Stop()
+
+	stopped := atomic.LoadInt32(&runs)
+	assert.GreaterOrEqual(t, stopped, int32(3))
+
+	// No more runs after Stop returns
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+}
+
+// TestRunnerSkipsDisabledJobs tests that a non-positive interval disables a job
+func TestRunnerSkipsDisabledJobs(t *testing.T) {
+	var runs int32
+	runner := jobs.NewRunner()
+	runner.Add("disabled", 0, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	runner.Start()
+	time.Sleep(20 * time.Millisecond)
+	runner.Stop()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&runs))
+}
+
+// TestRunnerStopWithoutStart tests that Stop is safe before Start
+func TestRunnerStopWithoutStart(t *testing.T) {
+	runner := jobs.NewRunner()
+	assert.NotPanics(t, runner.Stop)
+}
@@ -0,0 +1,53 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/jobs"
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTrashPurgerPurgesExpiredTrash tests that only content trashed before
+// the retention period is purged, together with what belongs to it
+func TestTrashPurgerPurgesExpiredTrash(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	expired := testdb.Post(t, db, author.ID, "expired", models.PostStatusPublished)
+	recent := testdb.Post(t, db, author.ID, "recent", models.PostStatusPublished)
+	live := testdb.Post(t, db, author.ID, "live", models.PostStatusPublished)
+	comment := models.Comment{Content: "A comment", UserID: author.ID, PostID: &expired.ID}
+	require.NoError(t, db.Omit("User", "Post", "Project", "Parent", "Children").Create(&comment).Error)
+
+	testdb.Trash(t, db, "posts", expired.ID, time.Now().Add(-31*24*time.Hour))
+	testdb.Trash(t, db, "posts", recent.ID, time.Now().Add(-29*24*time.Hour))
+
+	require.NoError(t, jobs.NewTrashPurger(db, 30*24*time.Hour).Run(context.Background()))
+
+	assert.False(t, testdb.Exists(t, db, "posts", expired.ID))
+	assert.False(t, testdb.Exists(t, db, "comments", comment.ID))
+	assert.True(t, testdb.Deleted(t, db, "posts", recent.ID))
+	assert.False(t, testdb.Deleted(t, db, "posts", live.ID))
+
+	var contributors int64
+	require.NoError(t, db.Model(&models.Contributor{}).Where("post_id = ?", expired.ID).Count(&contributors).Error)
+	assert.Zero(t, contributors)
+}
+
+// TestTrashPurgerKeepsTrashWithoutRetention tests that a non-positive
+// retention keeps the trash forever
+func TestTrashPurgerKeepsTrashWithoutRetention(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "old", models.PostStatusPublished)
+	testdb.Trash(t, db, "posts", post.ID, time.Now().Add(-365*24*time.Hour))
+
+	require.NoError(t, jobs.NewTrashPurger(db, 0).Run(context.Background()))
+
+	assert.True(t, testdb.Deleted(t, db, "posts", post.ID))
+}
@@ -0,0 +1,164 @@
+package lint_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"codewithdell/backend/internal/lint"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSite knows a fixed set of slugs and uploads
+type fakeSite struct {
+	slugs   map[string][]string
+	uploads []string
+	lookups int
+	err     error
+}
+
+func (s *fakeSite) MissingSlugs(kind string, slugs []string) ([]string, error) {
+	s.lookups++
+	if s.err != nil {
+		return nil, s.err
+	}
+	var missing []string
+	for _, slug := range slugs {
+		found := false
+		for _, known := range s.slugs[kind] {
+			found = found || known == slug
+		}
+		if !found {
+			missing = append(missing, slug)
+		}
+	}
+	return missing, nil
+}
+
+func (s *fakeSite) UploadExists(path string) bool {
+	for _, upload := range s.uploads {
+		if upload == path {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanPost returns a post without issues
+func cleanPost() lint.Post {
+	return lint.Post{
+		Title:      "Getting Started with Go",
+		Excerpt:    "A short introduction",
+		Content:    "## Setup\n\nInstall Go first.\n\n### Modules\n\nThen create a module.\n",
+		Tags:       2,
+		Categories: 1,
+	}
+}
+
+// rules returns the rules of the issues in a report
+func rules(report *lint.Report) []string {
+	var names []string
+	for _, issue := range report.Issues {
+		names = append(names, issue.Rule)
+	}
+	return names
+}
+
+// TestCheckCleanPost tests that a complete post has no issues
+func TestCheckCleanPost(t *testing.T) {
+	report, err := lint.Check(cleanPost(), &fakeSite{})
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Issues)
+	assert.False(t, report.HasErrors())
+}
+
+// TestCheckBrokenLinks tests that links to unknown slugs are errors, once per slug
+func TestCheckBrokenLinks(t *testing.T) {
+	post := cleanPost()
+	post.Content += "\nSee [part one](/blog/part-one), [part two](/blog/part-two?ref=1#top), " +
+		"[again](/posts/part-two), [the app](/projects/app/), [go](/blog/tag/go) and " +
+		"<a href=\"/blog/category/missing\">more</a>. [Elsewhere](https://example.com/blog/nope) " +
+		"and [the list](/blog) are not checked.\n\n```\n[code](/blog/in-code)\n```\n"
+	site := &fakeSite{slugs: map[string][]string{
+		lint.KindPost:    {"part-one"},
+		lint.KindProject: {"app"},
+		lint.KindTag:     {"go"},
+	}}
+
+	report, err := lint.Check(post, site)
+	require.NoError(t, err)
+
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, lint.RuleBrokenLink, report.Issues[0].Rule)
+	assert.Equal(t, lint.SeverityError, report.Issues[0].Severity)
+	assert.Equal(t, "/blog/part-two?ref=1#top", report.Issues[0].Target)
+	assert.Equal(t, "/blog/category/missing", report.Issues[1].Target)
+	assert.Equal(t, 2, report.Errors)
+	assert.True(t, report.HasErrors())
+	assert.Equal(t, 4, site.lookups)
+}
+
+// TestCheckLookupFailure tests that failing slug lookups are returned
+func TestCheckLookupFailure(t *testing.T) {
+	post := cleanPost()
+	post.Content += "\n[part one](/blog/part-one)\n"
+
+	_, err := lint.Check(post, &fakeSite{err: errors.New("database down")})
+	assert.Error(t, err)
+}
+
+// TestCheckImages tests missing alt text and references to deleted uploads
+func TestCheckImages(t *testing.T) {
+	post := cleanPost()
+	post.Content += "\n![Diagram](/uploads/images/diagram.png)\n\n![](/uploads/images/gone.png)\n\n" +
+		"<img src=\"https://example.com/a.png\" alt=\"\">\n\n[Slides](/uploads/files/slides.pdf)\n"
+	site := &fakeSite{uploads: []string{"/uploads/images/diagram.png", "/uploads/files/slides.pdf"}}
+
+	report, err := lint.Check(post, site)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{lint.RuleMissingUpload, lint.RuleMissingAlt, lint.RuleMissingAlt}, rules(report))
+	assert.Equal(t, "/uploads/images/gone.png", report.Issues[0].Target)
+	assert.Equal(t, "https://example.com/a.png", report.Issues[2].Target)
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 2, report.Warnings)
+}
+
+// TestCheckHeadingJumps tests that headings may not skip a level below the previous one
+func TestCheckHeadingJumps(t *testing.T) {
+	post := cleanPost()
+	post.Content = "### Too deep\n\ntext\n\n## Back up\n\n### Fine\n\n##### Skipped\n\ntext\n"
+
+	report, err := lint.Check(post, &fakeSite{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{lint.RuleHeadingJump, lint.RuleHeadingJump}, rules(report))
+	assert.Equal(t, "Too deep", report.Issues[0].Target)
+	assert.Equal(t, "Skipped", report.Issues[1].Target)
+	assert.False(t, report.HasErrors())
+}
+
+// TestCheckMetadata tests long titles and excerpts, missing taxonomy and short content
+func TestCheckMetadata(t *testing.T) {
+	post := lint.Post{
+		Title:   strings.Repeat("a", lint.MaxTitleLength+1),
+		Excerpt: strings.Repeat("b", lint.MaxExcerptLength+1),
+		Content: "Too short",
+	}
+
+	report, err := lint.Check(post, &fakeSite{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		lint.RuleContentLength,
+		lint.RuleTitleLength,
+		lint.RuleExcerptLength,
+		lint.RuleMissingTags,
+		lint.RuleMissingCategories,
+	}, rules(report))
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 4, report.Warnings)
+}
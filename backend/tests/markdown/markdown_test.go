@@ -0,0 +1,98 @@
+package markdown_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/markdown"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenderAddsHeadingAnchorsAndTOC tests that headings get ids and are nested in the TOC
+func TestRenderAddsHeadingAnchorsAndTOC(t *testing.T) {
+	source := "# Intro\n\n## Setup\n\n### Install\n\n## Usage\n\n# Summary\n"
+
+	doc, err := markdown.NewRenderer().Render(source)
+	require.NoError(t, err)
+
+	assert.Contains(t, doc.HTML, `<h2 id="setup">Setup</h2>`)
+	require.Len(t, doc.TOC, 2)
+	assert.Equal(t, "intro", doc.TOC[0].ID)
+	require.Len(t, doc.TOC[0].Children, 2)
+	assert.Equal(t, "Setup", doc.TOC[0].Children[0].Text)
+	assert.Equal(t, "install", doc.TOC[0].Children[0].Children[0].ID)
+	assert.Equal(t, "usage", doc.TOC[0].Children[1].ID)
+	assert.Equal(t, "Summary", doc.TOC[1].Text)
+}
+
+// TestRenderHighlightsCode tests that fenced code blocks are highlighted with classes
+func TestRenderHighlightsCode(t *testing.T) {
+	source := "```go\nfunc main() {}\n```\n"
+
+	doc, err := markdown.NewRenderer().Render(source)
+	require.NoError(t, err)
+
+	assert.Contains(t, doc.HTML, `<pre class="chroma">`)
+	assert.Contains(t, doc.HTML, `<span class="kd">func</span>`)
+}
+
+// TestRenderSanitizesHTML tests that scripts and event handlers are stripped
+func TestRenderSanitizesHTML(t *testing.T) {
+	source := "Hello <script>alert(1)</script><img src=\"/a.png\" onerror=\"alert(2)\">\n\n[link](javascript:alert(3))\n"
+
+	doc, err := markdown.NewRenderer().Render(source)
+	require.NoError(t, err)
+
+	assert.NotContains(t, doc.HTML, "<script")
+	assert.NotContains(t, doc.HTML, "onerror")
+	assert.NotContains(t, doc.HTML, "javascript:")
+	assert.Contains(t, doc.HTML, `<img src="/a.png">`)
+}
+
+// TestCacheWithoutRedisRenders tests that a cache without a client renders every call
+func TestCacheWithoutRedisRenders(t *testing.T) {
+	cache := markdown.NewCache(markdown.NewRenderer(), nil, "markdown:test", time.Minute)
+
+	doc, err := cache.Render(context.Background(), 1, "# Title\n")
+	require.NoError(t, err)
+	assert.Contains(t, doc.HTML, `<h1 id="title">Title</h1>`)
+	assert.NoError(t, cache.Invalidate(context.Background(), 1))
+}
+
+// TestAnalyzeIgnoresCodeAndSyntax tests that only prose words are counted
+func TestAnalyzeIgnoresCodeAndSyntax(t *testing.T) {
+	source := "# Getting **Started**\n\nGo is [a language](https://go.dev) with `fmt`.\n\n```go\nfunc main() { fmt.Println(\"not counted\") }\n```\n\n- one\n- two\n\n![diagram](/a.png)\n"
+
+	summary := markdown.Analyze(source)
+
+	assert.Equal(t, 10, summary.WordCount)
+	assert.Equal(t, 1, summary.ReadingTime)
+	assert.Equal(t, "Go is a language with fmt.", summary.Excerpt)
+}
+
+// TestAnalyzeReadingTimeAndExcerptLength tests reading time rounding and excerpt truncation
+func TestAnalyzeReadingTimeAndExcerptLength(t *testing.T) {
+	source := strings.Repeat("word ", 401)
+
+	summary := markdown.Analyze(source)
+
+	assert.Equal(t, 401, summary.WordCount)
+	assert.Equal(t, 3, summary.ReadingTime)
+	assert.LessOrEqual(t, len(summary.Excerpt), markdown.ExcerptLength)
+	assert.True(t, strings.HasSuffix(summary.Excerpt, "word..."))
+	assert.Equal(t, 0, markdown.Analyze("").ReadingTime)
+}
+
+// TestAnalyzeWithoutProse tests that content with only code or images still
+// takes a minute to read
+func TestAnalyzeWithoutProse(t *testing.T) {
+	summary := markdown.Analyze("```go\nfunc main() {}\n```\n\n![diagram](/a.png)\n")
+
+	assert.Equal(t, 0, summary.WordCount)
+	assert.Equal(t, 1, summary.ReadingTime)
+	assert.Equal(t, 0, markdown.Analyze(" \n\n").ReadingTime)
+}
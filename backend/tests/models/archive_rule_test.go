@@ -0,0 +1,29 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestArchiveRuleCutoff tests that the cutoff lies the rule's age in months before now
+func TestArchiveRuleCutoff(t *testing.T) {
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	rule := models.ArchiveRule{MaxAgeMonths: 3}
+
+	assert.Equal(t, time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC), rule.Cutoff(now))
+}
+
+// TestPostIsExpired tests that posts expire once their expiry time is reached
+func TestPostIsExpired(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	assert.False(t, (&models.Post{}).IsExpired(now))
+	assert.False(t, (&models.Post{ExpiresAt: &future}).IsExpired(now))
+	assert.True(t, (&models.Post{ExpiresAt: &past}).IsExpired(now))
+	assert.True(t, (&models.Post{ExpiresAt: &now}).IsExpired(now))
+}
@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"testing"
+
+	"codewithdell/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+// TestBuildCategoryTree tests that categories are nested under their parents in list order
+func TestBuildCategoryTree(t *testing.T) {
+	categories := []models.Category{
+		{ID: 1, Name: "Backend"},
+		{ID: 2, Name: "Databases", ParentID: uintPtr(1)},
+		{ID: 3, Name: "Frontend"},
+		{ID: 4, Name: "Go", ParentID: uintPtr(1)},
+		{ID: 5, Name: "Postgres", ParentID: uintPtr(2)},
+		{ID: 6, Name: "Orphan", ParentID: uintPtr(99)},
+	}
+
+	tree := models.BuildCategoryTree(categories)
+
+	require.Len(t, tree, 3)
+	assert.Equal(t, "Backend", tree[0].Name)
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, "Databases", tree[0].Children[0].Name)
+	assert.Equal(t, "Postgres", tree[0].Children[0].Children[0].Name)
+	assert.Equal(t, "Go", tree[0].Children[1].Name)
+	assert.Empty(t, tree[1].Children)
+	assert.Equal(t, "Orphan", tree[2].Name)
+}
+
+// TestCategoryDescendantIDs tests that all descendants are found and cycles terminate
+func TestCategoryDescendantIDs(t *testing.T) {
+	categories := []models.Category{
+		{ID: 1},
+		{ID: 2, ParentID: uintPtr(1)},
+		{ID: 3, ParentID: uintPtr(2)},
+		{ID: 4, ParentID: uintPtr(1)},
+		{ID: 5},
+		{ID: 6, ParentID: uintPtr(7)},
+		{ID: 7, ParentID: uintPtr(6)},
+	}
+
+	assert.Equal(t, []uint{2, 4, 3}, models.CategoryDescendantIDs(categories, 1))
+	assert.Empty(t, models.CategoryDescendantIDs(categories, 5))
+	assert.Equal(t, []uint{7}, models.CategoryDescendantIDs(categories, 6))
+}
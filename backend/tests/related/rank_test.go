@@ -0,0 +1,40 @@
+package related_test
+
+import (
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/related"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRankPrefersSharedTaxonomy tests that shared tags outweigh shared categories and recency
+func TestRankPrefersSharedTaxonomy(t *testing.T) {
+	now := time.Now()
+	target := related.Item{ID: 1, Title: "Building REST APIs in Go"}
+	candidates := []related.Item{
+		{ID: 2, Title: "Gardening notes", Date: now},
+		{ID: 3, Title: "Deploying services", SharedCategories: 1, Date: now.AddDate(0, -6, 0)},
+		{ID: 4, Title: "Middleware patterns", SharedTags: 2, Date: now.AddDate(-1, 0, 0)},
+	}
+
+	assert.Equal(t, []uint{4, 3, 2}, related.Rank(target, candidates, now, 10))
+}
+
+// TestRankUsesTextSimilarityAndRecency tests tie breaking by title similarity, then by date
+func TestRankUsesTextSimilarityAndRecency(t *testing.T) {
+	now := time.Now()
+	target := related.Item{ID: 1, Title: "Testing HTTP handlers", Excerpt: "Table driven tests for Gin handlers"}
+	candidates := []related.Item{
+		{ID: 1, Title: "Testing HTTP handlers", SharedTags: 5},
+		{ID: 2, Title: "Release notes", Date: now.AddDate(0, 0, -1)},
+		{ID: 3, Title: "Mocking HTTP clients", Excerpt: "Testing without a network", Date: now.AddDate(0, -3, 0)},
+		{ID: 4, Title: "Release notes", Date: now.AddDate(0, 0, -10)},
+	}
+
+	ranked := related.Rank(target, candidates, now, 2)
+
+	assert.Equal(t, []uint{3, 2}, ranked)
+	assert.NotContains(t, ranked, uint(1))
+}
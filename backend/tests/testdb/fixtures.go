@@ -0,0 +1,86 @@
+package testdb
+
+import (
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// User creates an active user with an email at example.com
+func User(t *testing.T, db *gorm.DB, username string) models.User {
+	t.Helper()
+	user := models.User{Email: username + "@example.com", Username: username, Password: "x", FirstName: username, LastName: "Test"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user %s: %v", username, err)
+	}
+	return user
+}
+
+// Post creates a post with the author as its only contributor
+func Post(t *testing.T, db *gorm.DB, authorID uint, slug string, status models.PostStatus) models.Post {
+	t.Helper()
+	now := time.Now()
+	post := models.Post{Title: slug, Slug: slug, Content: "Content of " + slug + ".", Status: status, AuthorID: authorID}
+	if status == models.PostStatusPublished {
+		post.PublishedAt = &now
+	}
+	if err := db.Omit(clause.Associations).Create(&post).Error; err != nil {
+		t.Fatalf("failed to create post %s: %v", slug, err)
+	}
+	contributor := models.Contributor{UserID: authorID, PostID: &post.ID, Role: models.ContributorRoleAuthor}
+	if err := db.Omit("User").Create(&contributor).Error; err != nil {
+		t.Fatalf("failed to credit post %s: %v", slug, err)
+	}
+	return post
+}
+
+// Project creates a project with the author as its only contributor
+func Project(t *testing.T, db *gorm.DB, authorID uint, slug string, status models.ProjectStatus) models.Project {
+	t.Helper()
+	now := time.Now()
+	project := models.Project{Title: slug, Slug: slug, Description: "Description of " + slug + ".", Status: status, AuthorID: authorID}
+	if status == models.ProjectStatusPublished {
+		project.PublishedAt = &now
+	}
+	if err := db.Omit(clause.Associations).Create(&project).Error; err != nil {
+		t.Fatalf("failed to create project %s: %v", slug, err)
+	}
+	contributor := models.Contributor{UserID: authorID, ProjectID: &project.ID, Role: models.ContributorRoleAuthor}
+	if err := db.Omit("User").Create(&contributor).Error; err != nil {
+		t.Fatalf("failed to credit project %s: %v", slug, err)
+	}
+	return project
+}
+
+// Trash soft-deletes the row of table with id at a time
+func Trash(t *testing.T, db *gorm.DB, table string, id uint, at time.Time) {
+	t.Helper()
+	if err := db.Table(table).Where("id = ?", id).Update("deleted_at", at).Error; err != nil {
+		t.Fatalf("failed to trash %s %d: %v", table, id, err)
+	}
+}
+
+// Deleted reports whether the row of table with id is soft-deleted, and
+// fails the test when it does not exist
+func Deleted(t *testing.T, db *gorm.DB, table string, id uint) bool {
+	t.Helper()
+	var rows []struct{ DeletedAt *time.Time }
+	if err := db.Table(table).Select("deleted_at").Where("id = ?", id).Scan(&rows).Error; err != nil || len(rows) == 0 {
+		t.Fatalf("failed to find %s %d: %v", table, id, err)
+	}
+	return rows[0].DeletedAt != nil
+}
+
+// Exists reports whether table has a row with id, deleted or not
+func Exists(t *testing.T, db *gorm.DB, table string, id uint) bool {
+	t.Helper()
+	var count int64
+	if err := db.Table(table).Where("id = ?", id).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count %s: %v", table, err)
+	}
+	return count > 0
+}
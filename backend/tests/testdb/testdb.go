@@ -0,0 +1,57 @@
+// Package testdb opens SQLite databases with the schema of the application,
+// for tests of code that queries the database. The Postgres functions the
+// queries use are registered as SQLite functions.
+package testdb
+
+import (
+	"database/sql/driver"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/config"
+	"codewithdell/backend/internal/database"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// timeFormat is the format the SQLite driver writes times in
+const timeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+func init() {
+	gosqlite.MustRegisterScalarFunction("now", 0, func(ctx *gosqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return time.Now().Format(timeFormat), nil
+	})
+	// Tests run one at a time, so the lock is always free
+	gosqlite.MustRegisterScalarFunction("pg_try_advisory_xact_lock", 1, func(ctx *gosqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return true, nil
+	})
+}
+
+// Open returns a migrated database in a temporary file, which is removed
+// when the test ends
+func Open(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	previous := database.DB
+	database.DB = db
+	defer func() { database.DB = previous }()
+	if err := database.RunMigrations(config.DatabaseConfig{}); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
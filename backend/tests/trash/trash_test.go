@@ -0,0 +1,194 @@
+package trash_test
+
+import (
+	"testing"
+	"time"
+
+	"codewithdell/backend/internal/models"
+	"codewithdell/backend/internal/trash"
+	"codewithdell/backend/tests/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// lookup returns the trash type with a route name
+func lookup(t *testing.T, name string) *trash.Type {
+	t.Helper()
+	typ, ok := trash.Lookup(name)
+	require.True(t, ok, name)
+	return typ
+}
+
+// category creates a category below a parent
+func category(t *testing.T, db *gorm.DB, slug string, parentID *uint) models.Category {
+	t.Helper()
+	category := models.Category{Name: slug, Slug: slug, ParentID: parentID}
+	require.NoError(t, db.Create(&category).Error)
+	return category
+}
+
+// comment creates a comment on a post in reply to a parent
+func comment(t *testing.T, db *gorm.DB, userID, postID uint, parentID *uint) models.Comment {
+	t.Helper()
+	comment := models.Comment{Content: "A comment", UserID: userID, PostID: &postID, ParentID: parentID}
+	require.NoError(t, db.Omit("User", "Post", "Project", "Parent", "Children").Create(&comment).Error)
+	return comment
+}
+
+// TestLookup tests that trash types resolve by their route name
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"posts", "projects", "categories", "tags", "comments"} {
+		assert.Equal(t, name, lookup(t, name).Name)
+	}
+
+	_, ok := trash.Lookup("users")
+	assert.False(t, ok)
+}
+
+// TestRestoreCategoryRestoresAncestors tests that restoring a category
+// restores its deleted ancestors, and nothing else
+func TestRestoreCategoryRestoresAncestors(t *testing.T) {
+	db := testdb.Open(t)
+	root := category(t, db, "root", nil)
+	middle := category(t, db, "middle", &root.ID)
+	leaf := category(t, db, "leaf", &middle.ID)
+	sibling := category(t, db, "sibling", &root.ID)
+	for _, id := range []uint{root.ID, middle.ID, leaf.ID, sibling.ID} {
+		testdb.Trash(t, db, "categories", id, time.Now())
+	}
+
+	restored, err := lookup(t, "categories").Restore(db, leaf.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int64{"categories": 2}, restored)
+	assert.False(t, testdb.Deleted(t, db, "categories", root.ID))
+	assert.False(t, testdb.Deleted(t, db, "categories", middle.ID))
+	assert.False(t, testdb.Deleted(t, db, "categories", leaf.ID))
+	assert.True(t, testdb.Deleted(t, db, "categories", sibling.ID))
+}
+
+// TestRestorePostRestoresTaxonomy tests that restoring a post restores its
+// deleted tags and the deleted chains of its categories
+func TestRestorePostRestoresTaxonomy(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	parent := category(t, db, "parent", nil)
+	child := category(t, db, "child", &parent.ID)
+	tag := models.Tag{Name: "go", Slug: "go"}
+	require.NoError(t, db.Create(&tag).Error)
+	require.NoError(t, db.Exec("INSERT INTO post_categories (post_id, category_id) VALUES (?, ?)", post.ID, child.ID).Error)
+	require.NoError(t, db.Exec("INSERT INTO post_tags (post_id, tag_id) VALUES (?, ?)", post.ID, tag.ID).Error)
+
+	testdb.Trash(t, db, "posts", post.ID, time.Now())
+	testdb.Trash(t, db, "categories", parent.ID, time.Now())
+	testdb.Trash(t, db, "categories", child.ID, time.Now())
+	testdb.Trash(t, db, "tags", tag.ID, time.Now())
+
+	restored, err := lookup(t, "posts").Restore(db, post.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int64{"tags": 1, "categories": 2}, restored)
+	assert.False(t, testdb.Deleted(t, db, "posts", post.ID))
+	assert.False(t, testdb.Deleted(t, db, "categories", parent.ID))
+	assert.False(t, testdb.Deleted(t, db, "tags", tag.ID))
+}
+
+// TestRestoreCommentRestoresThread tests that restoring a reply restores the
+// deleted comments it replies to
+func TestRestoreCommentRestoresThread(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	first := comment(t, db, author.ID, post.ID, nil)
+	second := comment(t, db, author.ID, post.ID, &first.ID)
+	reply := comment(t, db, author.ID, post.ID, &second.ID)
+	for _, id := range []uint{first.ID, second.ID, reply.ID} {
+		testdb.Trash(t, db, "comments", id, time.Now())
+	}
+
+	restored, err := lookup(t, "comments").Restore(db, reply.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int64{"comments": 2}, restored)
+	for _, id := range []uint{first.ID, second.ID, reply.ID} {
+		assert.False(t, testdb.Deleted(t, db, "comments", id))
+	}
+}
+
+// TestRestoreCommentOfTrashedPost tests that a comment cannot be restored
+// while its post is in the trash, which the API answers with 409 Conflict
+func TestRestoreCommentOfTrashedPost(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	reply := comment(t, db, author.ID, post.ID, nil)
+	testdb.Trash(t, db, "posts", post.ID, time.Now())
+	testdb.Trash(t, db, "comments", reply.ID, time.Now())
+
+	_, err := lookup(t, "comments").Restore(db, reply.ID)
+
+	var conflict *trash.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Contains(t, conflict.Reason, "post of this comment is in the trash")
+	assert.True(t, testdb.Deleted(t, db, "comments", reply.ID))
+}
+
+// TestRestoreOutsideTrash tests that rows that are not trashed are not found
+func TestRestoreOutsideTrash(t *testing.T) {
+	db := testdb.Open(t)
+	tag := models.Tag{Name: "go", Slug: "go"}
+	require.NoError(t, db.Create(&tag).Error)
+
+	_, err := lookup(t, "tags").Restore(db, tag.ID)
+	assert.ErrorIs(t, err, trash.ErrNotFound)
+	assert.ErrorIs(t, lookup(t, "tags").Purge(db, tag.ID), trash.ErrNotFound)
+	assert.True(t, testdb.Exists(t, db, "tags", tag.ID))
+}
+
+// TestPurgeCommentKeepsReplies tests that replies to a purged comment move
+// up to the comment it replied to
+func TestPurgeCommentKeepsReplies(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	first := comment(t, db, author.ID, post.ID, nil)
+	purged := comment(t, db, author.ID, post.ID, &first.ID)
+	reply := comment(t, db, author.ID, post.ID, &purged.ID)
+	testdb.Trash(t, db, "comments", purged.ID, time.Now())
+
+	require.NoError(t, lookup(t, "comments").Purge(db, purged.ID))
+
+	assert.False(t, testdb.Exists(t, db, "comments", purged.ID))
+	var moved models.Comment
+	require.NoError(t, db.First(&moved, reply.ID).Error)
+	require.NotNil(t, moved.ParentID)
+	assert.Equal(t, first.ID, *moved.ParentID)
+}
+
+// TestPurgeCategoryKeepsSubcategories tests that subcategories of a purged
+// category move up to its parent and that posts lose the category
+func TestPurgeCategoryKeepsSubcategories(t *testing.T) {
+	db := testdb.Open(t)
+	author := testdb.User(t, db, "author")
+	post := testdb.Post(t, db, author.ID, "post", models.PostStatusPublished)
+	root := category(t, db, "root", nil)
+	purged := category(t, db, "purged", &root.ID)
+	leaf := category(t, db, "leaf", &purged.ID)
+	require.NoError(t, db.Exec("INSERT INTO post_categories (post_id, category_id) VALUES (?, ?)", post.ID, purged.ID).Error)
+	testdb.Trash(t, db, "categories", purged.ID, time.Now())
+
+	require.NoError(t, lookup(t, "categories").Purge(db, purged.ID))
+
+	assert.False(t, testdb.Exists(t, db, "categories", purged.ID))
+	var moved models.Category
+	require.NoError(t, db.First(&moved, leaf.ID).Error)
+	require.NotNil(t, moved.ParentID)
+	assert.Equal(t, root.ID, *moved.ParentID)
+
+	var links int64
+	require.NoError(t, db.Table("post_categories").Where("category_id = ?", purged.ID).Count(&links).Error)
+	assert.Zero(t, links)
+}
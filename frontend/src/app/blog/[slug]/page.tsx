@@ -4,7 +4,9 @@ import { useState, useEffect } from 'react';
 import { useParams } from 'next/navigation';
 import Link from 'next/link';
 import { Button } from '@/components/ui/Button';
-import { API_ENDPOINTS, fetchAPI } from '@/lib/api';
+import { PostCard } from '@/components/ui/PostCard';
+import { API_ENDPOINTS, apiClient, fetchAPI } from '@/lib/api';
+import { Post } from '@/types/api';
 
 interface BlogPost {
   id: number;
@@ -26,12 +28,14 @@ export default function BlogPostPage() {
   const slug = params.slug as string;
   
   const [post, setPost] = useState<BlogPost | null>(null);
+  const [relatedPosts, setRelatedPosts] = useState<Post[]>([]);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
 
   useEffect(() => {
     if (slug) {
       fetchPost(slug);
+      fetchRelatedPosts(slug);
     }
   }, [slug]);
 
@@ -46,6 +50,16 @@ export default function BlogPostPage() {
     }
   };
 
+  const fetchRelatedPosts = async (postSlug: string) => {
+    try {
+      const data = await apiClient.getRelatedPosts(postSlug);
+      setRelatedPosts(data.posts);
+    } catch {
+      // Related posts are optional; the article renders without them
+      setRelatedPosts([]);
+    }
+  };
+
   const formatDate = (dateString: string) => {
     return new Date(dateString).toLocaleDateString('en-US', {
       year: 'numeric',
@@ -165,6 +179,22 @@ export default function BlogPostPage() {
         </div>
       </section>
 
+      {/* Related Posts */}
+      {relatedPosts.length > 0 && (
+        <section className="pb-16">
+          <div className="max-w-4xl mx-auto px-4 sm:px-6 lg:px-8">
+            <h2 className="text-2xl font-bold text-gray-900 dark:text-white mb-6">
+              Related Posts
+            </h2>
+            <div className="grid grid-cols-1 md:grid-cols-2 gap-6">
+              {relatedPosts.map((relatedPost) => (
+                <PostCard key={relatedPost.id} post={relatedPost} showStats={false} />
+              ))}
+            </div>
+          </div>
+        </section>
+      )}
+
       {/* CTA Section */}
       <section className="bg-white dark:bg-gray-800 py-16">
         <div className="max-w-4xl mx-auto px-4 sm:px-6 lg:px-8 text-center">
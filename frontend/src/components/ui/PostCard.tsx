@@ -184,6 +184,7 @@ export const PostCard: React.FC<PostCardProps> = ({
               </div>
               <div className="text-xs text-gray-500">
                 {formatDate(post.created_at)}
+                {post.reading_time > 0 && ` · ${post.reading_time} min read`}
               </div>
             </div>
           </div>
@@ -18,6 +18,9 @@ import {
   SearchResponse,
   AnalyticsResponse,
   UploadResponse,
+  InteractionsResponse,
+  FeaturedResponse,
+  PostLintResponse,
 } from '@/types/api';
 
 // API Configuration
@@ -35,6 +38,7 @@ export const API_ENDPOINTS = {
   POSTS: {
     LIST: `${API_BASE_URL}/api/v1/posts`,
     BY_SLUG: (slug: string) => `${API_BASE_URL}/api/v1/posts/${slug}`,
+    RELATED: (slug: string) => `${API_BASE_URL}/api/v1/posts/${slug}/related`,
     CREATE: `${API_BASE_URL}/api/v1/admin/posts`,
     UPDATE: (id: number) => `${API_BASE_URL}/api/v1/admin/posts/${id}`,
     DELETE: (id: number) => `${API_BASE_URL}/api/v1/admin/posts/${id}`,
@@ -228,6 +232,10 @@ class ApiClient {
     return this.request<Post>(`/api/v1/posts/${slug}`);
   }
 
+  async getRelatedPosts(slug: string, limit = 4): Promise<{ posts: Post[]; total: number }> {
+    return this.request<{ posts: Post[]; total: number }>(`/api/v1/posts/${slug}/related?limit=${limit}`);
+  }
+
   // Categories endpoints
   async getCategories(): Promise<{ categories: Category[]; total: number }> {
     return this.request<{ categories: Category[]; total: number }>('/api/v1/categories');
@@ -316,12 +324,17 @@ class ApiClient {
     return this.request<{ is_liked: boolean; is_bookmarked: boolean }>(`/api/v1/interactions/posts/${postId}/check`);
   }
 
-  async getUserLikes(): Promise<{ posts: Post[]; total: number }> {
-    return this.request<{ posts: Post[]; total: number }>('/api/v1/interactions/likes');
+  async getUserLikes(): Promise<InteractionsResponse> {
+    return this.request<InteractionsResponse>('/api/v1/interactions/likes');
+  }
+
+  async getUserBookmarks(): Promise<InteractionsResponse> {
+    return this.request<InteractionsResponse>('/api/v1/interactions/bookmarks');
   }
 
-  async getUserBookmarks(): Promise<{ posts: Post[]; total: number }> {
-    return this.request<{ posts: Post[]; total: number }>('/api/v1/interactions/bookmarks');
+  // Featured endpoints
+  async getFeatured(): Promise<FeaturedResponse> {
+    return this.request<FeaturedResponse>('/api/v1/featured');
   }
 
   // Search endpoints
@@ -465,19 +478,26 @@ class ApiClient {
     });
   }
 
-  async updatePost(id: number, data: UpdatePostRequest): Promise<Post> {
+  // version is the post version the change is based on, sent as If-Match
+  async updatePost(id: number, data: UpdatePostRequest, version: number): Promise<Post> {
     return this.request<Post>(`/api/v1/admin/posts/${id}`, {
       method: 'PUT',
+      headers: { 'Content-Type': 'application/json', 'If-Match': `"${version}"` },
       body: JSON.stringify(data),
     });
   }
 
-  async deletePost(id: number): Promise<void> {
+  async deletePost(id: number, version: number): Promise<void> {
     return this.request<void>(`/api/v1/admin/posts/${id}`, {
       method: 'DELETE',
+      headers: { 'If-Match': `"${version}"` },
     });
   }
 
+  async lintPost(id: number): Promise<PostLintResponse> {
+    return this.request<PostLintResponse>(`/api/v1/admin/posts/${id}/lint`);
+  }
+
   // Health check
   async healthCheck(): Promise<{ message: string; service: string; timestamp: string }> {
     return this.request('/api/v1/test');
@@ -26,17 +26,101 @@ export interface Post {
   content: string;
   excerpt?: string;
   featured_image?: string;
-  status: 'draft' | 'published' | 'archived';
+  status: 'draft' | 'published' | 'archived' | 'scheduled';
   published_at?: string;
+  expires_at?: string | null;
+  archived?: boolean;
   author_id: number;
   view_count: number;
   like_count: number;
   comment_count: number;
+  word_count: number;
+  reading_time: number;
+  series_id?: number | null;
+  series_position: number;
+  series?: SeriesNavigation | null;
+  version: number;
   created_at: string;
   updated_at: string;
   author: User;
+  contributors?: Contributor[];
   categories: Category[];
   tags: Tag[];
+  locale?: string;
+  alternates?: Alternate[];
+}
+
+// Localization types
+export interface Alternate {
+  hreflang: string;
+  slug: string;
+  href: string;
+}
+
+export interface Translation {
+  id: number;
+  entity_type: 'post' | 'project' | 'category' | 'tag';
+  entity_id: number;
+  locale: string;
+  title: string;
+  slug: string;
+  content: string;
+  excerpt: string;
+  created_at: string;
+  updated_at: string;
+}
+
+// Returned with a 301 when a post, tag or category is requested by a previous slug
+export interface SlugRedirect {
+  message: string;
+  redirect: {
+    type: 'post' | 'tag' | 'category';
+    slug: string;
+    location: string;
+  };
+}
+
+// Contributor types
+export interface Contributor {
+  id: number;
+  user_id: number;
+  post_id?: number;
+  project_id?: number;
+  role: 'author' | 'editor' | 'illustrator' | 'reviewer';
+  position: number;
+  created_at: string;
+  user: User;
+}
+
+// Series types
+export interface Series {
+  id: number;
+  uuid: string;
+  title: string;
+  slug: string;
+  description?: string;
+  featured_image?: string;
+  post_count: number;
+  created_at: string;
+  updated_at: string;
+  posts?: Post[];
+}
+
+export interface SeriesPostLink {
+  id: number;
+  title: string;
+  slug: string;
+  position: number;
+}
+
+export interface SeriesNavigation {
+  id: number;
+  title: string;
+  slug: string;
+  position: number;
+  total: number;
+  previous: SeriesPostLink | null;
+  next: SeriesPostLink | null;
 }
 
 // Category types
@@ -48,8 +132,14 @@ export interface Category {
   description?: string;
   color?: string;
   icon?: string;
+  parent_id?: number | null;
+  version: number;
   created_at: string;
   updated_at: string;
+  parent?: Category;
+  children?: Category[];
+  locale?: string;
+  alternates?: Alternate[];
 }
 
 // Tag types
@@ -59,8 +149,11 @@ export interface Tag {
   name: string;
   slug: string;
   color?: string;
+  version: number;
   created_at: string;
   updated_at: string;
+  locale?: string;
+  alternates?: Alternate[];
 }
 
 // Comment types
@@ -88,16 +181,47 @@ export interface Project {
   description: string;
   content: string;
   featured_image?: string;
-  status: 'draft' | 'published' | 'archived';
+  status: 'draft' | 'published' | 'archived' | 'scheduled';
   published_at?: string;
   view_count: number;
   like_count: number;
   comment_count: number;
+  version: number;
   created_at: string;
   updated_at: string;
   technologies: Technology[];
   tags: Tag[];
   categories: Category[];
+  contributors?: Contributor[];
+  locale?: string;
+  alternates?: Alternate[];
+}
+
+// Interaction types
+export interface InteractionItem {
+  type: 'post' | 'project';
+  created_at: string;
+  post?: Post;
+  project?: Project;
+}
+
+export interface InteractionsResponse {
+  items: InteractionItem[];
+  total: number;
+}
+
+// Featured content types
+export interface FeaturedItem {
+  slot_id: number;
+  position: number;
+  type: 'post' | 'project';
+  post?: Post;
+  project?: Project;
+}
+
+export interface FeaturedResponse {
+  items: FeaturedItem[];
+  total: number;
 }
 
 // Technology types
@@ -140,9 +264,9 @@ export interface CreatePostRequest {
   content: string;
   excerpt?: string;
   slug?: string;
-  status: 'draft' | 'published' | 'archived';
+  status: 'draft' | 'published' | 'archived' | 'scheduled';
   tag_ids?: string[];
-  category_ids?: string[];
+  category_ids?: number[];
 }
 
 export interface UpdatePostRequest {
@@ -150,9 +274,29 @@ export interface UpdatePostRequest {
   content?: string;
   excerpt?: string;
   slug?: string;
-  status?: 'draft' | 'published' | 'archived';
+  status?: 'draft' | 'published' | 'archived' | 'scheduled';
   tag_ids?: string[];
-  category_ids?: string[];
+  category_ids?: number[];
+}
+
+// Lint types
+export interface LintIssue {
+  rule: string;
+  severity: 'error' | 'warning';
+  message: string;
+  target?: string;
+}
+
+export interface LintReport {
+  issues: LintIssue[];
+  errors: number;
+  warnings: number;
+}
+
+export interface PostLintResponse {
+  post_id: number;
+  report: LintReport;
+  publish_blocked: boolean;
 }
 
 export interface PostsResponse {